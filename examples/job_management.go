@@ -211,11 +211,11 @@ func demonstrateJobManagement(jm *jobs.ZOSMFJobManager) {
 	fmt.Println("   For demonstration, showing the timeout and polling logic:")
 	
 	// This would be used in a real scenario:
-	// status, err := jm.WaitForJobCompletion("JOB001", 5*time.Minute, 10*time.Second)
+	// result, err := jm.WaitForJobCompletion("JOB001", 5*time.Minute, 10*time.Second)
 	// if err != nil {
 	//     fmt.Printf("   Error waiting for job completion: %v\n", err)
 	// } else {
-	//     fmt.Printf("   Job completed with status: %s\n", status)
+	//     fmt.Printf("   Job completed with %s (succeeded: %t)\n", result.RetCode, result.Succeeded)
 	// }
 
 	// Example 17: Close job manager
@@ -0,0 +1,144 @@
+package console
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestProfile creates a profile for testing with the given server URL
+func createTestProfile(serverURL string) *profile.ZOSMFProfile {
+	// Extract host and port from server URL
+	host := strings.TrimPrefix(serverURL, "http://")
+	host = strings.TrimPrefix(host, "https://")
+
+	return &profile.ZOSMFProfile{
+		Name:               "test",
+		Host:               host,
+		Port:               0, // Let the session determine the port from the URL
+		User:               "testuser",
+		Password:           "testpass",
+		RejectUnauthorized: false,
+		BasePath:           "/api/v1",
+		Protocol:           "http", // Force HTTP for test server
+	}
+}
+
+// newTestManager creates a console manager pointed at a test server
+func newTestManager(t *testing.T, handler http.HandlerFunc) (*ZOSMFConsoleManager, func()) {
+	server := httptest.NewServer(handler)
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	return NewConsoleManager(session), server.Close
+}
+
+func TestNewConsoleManager(t *testing.T) {
+	session, err := createTestProfile("localhost:8080").NewSession()
+	require.NoError(t, err)
+
+	cm := NewConsoleManager(session)
+	assert.NotNil(t, cm)
+	assert.Equal(t, session, cm.session)
+
+	cm, err = NewConsoleManagerFromProfile(createTestProfile("localhost:8080"))
+	require.NoError(t, err)
+	assert.NotNil(t, cm)
+}
+
+func TestNewConsoleManagerNilSession(t *testing.T) {
+	// A manager built without a session reports an error instead of panicking
+	cm := NewConsoleManager(nil)
+
+	_, err := cm.IssueCommand("", "D T")
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	assert.NoError(t, cm.CloseConsoleManager())
+}
+
+func TestCreateConsoleManager(t *testing.T) {
+	pm := &profile.ZOSMFProfileManager{}
+
+	// This should fail since we don't have a real profile
+	_, err := CreateConsoleManager(pm, "nonexistent")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get ZOSMF profile")
+
+	cm, err := CreateConsoleManagerDirect("localhost", 8080, "testuser", "testpass")
+	require.NoError(t, err)
+	assert.NotNil(t, cm)
+
+	cm, err = CreateConsoleManagerDirectWithOptions("localhost", 8080, "testuser", "testpass", false, "/api/v1")
+	require.NoError(t, err)
+	assert.NotNil(t, cm)
+}
+
+func TestIssueCommand(t *testing.T) {
+	cm, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restconsoles/consoles/defcn", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"cmd": "D IPLINFO"}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"cmd-response-key":"C1977656","cmd-response-url":"https://host/zosmf/restconsoles/consoles/defcn/solmsgs/C1977656",` +
+			`"cmd-response-uri":"/zosmf/restconsoles/consoles/defcn/solmsgs/C1977656","cmd-response":" IEE254I  10.15.00 IPLINFO DISPLAY"}`))
+	})
+	defer closeServer()
+
+	resp, err := cm.IssueCommand("", "D IPLINFO")
+	require.NoError(t, err)
+	assert.Equal(t, "C1977656", resp.CommandResponseKey)
+	assert.Contains(t, resp.CommandResponse, "IPLINFO DISPLAY")
+
+	_, err = cm.IssueCommand("", "")
+	assert.Error(t, err)
+}
+
+func TestIssueCommandAsyncAndGetResponse(t *testing.T) {
+	cm, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "PUT":
+			assert.Equal(t, "/api/v1/restconsoles/consoles/MYCONS", r.URL.Path)
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Y", body["async"])
+			w.Write([]byte(`{"cmd-response-key":"C2000001","cmd-response-uri":"/zosmf/restconsoles/consoles/MYCONS/solmsgs/C2000001"}`))
+		case "GET":
+			assert.Equal(t, "/api/v1/restconsoles/consoles/MYCONS/solmsgs/C2000001", r.URL.Path)
+			w.Write([]byte(`{"cmd-response":" IEE114I 10.16.00 ACTIVITY","sol-key-detected":false}`))
+		}
+	})
+	defer closeServer()
+
+	key, err := cm.IssueCommandAsync("MYCONS", "D A,L")
+	require.NoError(t, err)
+	assert.Equal(t, "C2000001", key)
+
+	resp, err := cm.GetResponse("MYCONS", key)
+	require.NoError(t, err)
+	assert.Contains(t, resp.CommandResponse, "ACTIVITY")
+
+	_, err = cm.GetResponse("MYCONS", "")
+	assert.Error(t, err)
+}
+
+func TestConsoleErrors(t *testing.T) {
+	cm, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"reason":"console name is not valid"}`))
+	})
+	defer closeServer()
+
+	_, err := cm.IssueCommand("BAD NAME", "D T")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
@@ -0,0 +1,52 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// CreateConsoleManager creates a console manager from a profile manager
+func CreateConsoleManager(pm *profile.ZOSMFProfileManager, profileName string) (*ZOSMFConsoleManager, error) {
+	zosmfProfile, err := pm.GetZOSMFProfile(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ZOSMF profile '%s': %w", profileName, err)
+	}
+
+	session, err := zosmfProfile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewConsoleManager(session), nil
+}
+
+// CreateConsoleManagerDirect creates a console manager with connection details
+func CreateConsoleManagerDirect(host string, port int, user, password string) (*ZOSMFConsoleManager, error) {
+	session, err := profile.CreateSessionDirect(host, port, user, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewConsoleManager(session), nil
+}
+
+// CreateConsoleManagerDirectWithOptions creates a console manager with extra options
+func CreateConsoleManagerDirectWithOptions(host string, port int, user, password string, rejectUnauthorized bool, basePath string) (*ZOSMFConsoleManager, error) {
+	session, err := profile.CreateSessionDirectWithOptions(host, port, user, password, rejectUnauthorized, basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewConsoleManager(session), nil
+}
+
+// IssueCommandAsync issues a command without waiting for its response.
+// Collect the output later with GetResponse and the returned key.
+func (cm *ZOSMFConsoleManager) IssueCommandAsync(consoleName, command string) (string, error) {
+	resp, err := cm.IssueCommandWithOptions(consoleName, &IssueRequest{Command: command, Async: "Y"})
+	if err != nil {
+		return "", err
+	}
+	return resp.CommandResponseKey, nil
+}
@@ -0,0 +1,124 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// z/OSMF console API endpoints
+const (
+	// Issue a command on a named console
+	ConsoleEndpoint = "/restconsoles/consoles/%s"
+
+	// Solicited messages for an earlier command response key
+	SolicitedMessagesEndpoint = "/restconsoles/consoles/%s/solmsgs/%s"
+)
+
+// DefaultConsoleName is the console z/OSMF uses when none is given
+const DefaultConsoleName = "defcn"
+
+// NewConsoleManager creates a console manager with the given session
+func NewConsoleManager(session *profile.Session) *ZOSMFConsoleManager {
+	return &ZOSMFConsoleManager{
+		session: session,
+	}
+}
+
+// getSession returns the manager's session, or profile.ErrNoSession when
+// the manager was created with a nil one
+func (cm *ZOSMFConsoleManager) getSession() (*profile.Session, error) {
+	if cm.session == nil {
+		return nil, profile.ErrNoSession
+	}
+	return cm.session, nil
+}
+
+// NewConsoleManagerFromProfile creates a console manager from a profile
+func NewConsoleManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFConsoleManager, error) {
+	session, err := profile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return NewConsoleManager(session), nil
+}
+
+// IssueCommand issues an MVS console command and returns the solicited
+// response. An empty console name uses DefaultConsoleName.
+func (cm *ZOSMFConsoleManager) IssueCommand(consoleName, command string) (*ConsoleResponse, error) {
+	return cm.IssueCommandWithOptions(consoleName, &IssueRequest{Command: command})
+}
+
+// IssueCommandWithOptions issues an MVS console command with extra options
+func (cm *ZOSMFConsoleManager) IssueCommandWithOptions(consoleName string, request *IssueRequest) (*ConsoleResponse, error) {
+	if request == nil || request.Command == "" {
+		return nil, fmt.Errorf("command cannot be empty")
+	}
+
+	session, err := cm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(ConsoleEndpoint, url.PathEscape(consoleNameOrDefault(consoleName)))
+	return cm.doConsole(session, "PUT", path, request)
+}
+
+// GetResponse retrieves solicited messages that arrived after an earlier
+// command returned, using its cmd-response-key
+func (cm *ZOSMFConsoleManager) GetResponse(consoleName, responseKey string) (*ConsoleResponse, error) {
+	if responseKey == "" {
+		return nil, fmt.Errorf("response key cannot be empty")
+	}
+
+	session, err := cm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(SolicitedMessagesEndpoint,
+		url.PathEscape(consoleNameOrDefault(consoleName)), url.PathEscape(responseKey))
+	return cm.doConsole(session, "GET", path, nil)
+}
+
+// doConsole sends a console request and decodes the response
+func (cm *ZOSMFConsoleManager) doConsole(session *profile.Session, method, path string, body interface{}) (*ConsoleResponse, error) {
+	var raw json.RawMessage
+	if err := session.DoJSON(context.Background(), method, path, body, &raw); err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var consoleResp ConsoleResponse
+	if err := profile.DecodeJSON(raw, &consoleResp, cm.StrictJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &consoleResp, nil
+}
+
+// consoleNameOrDefault falls back to DefaultConsoleName
+func consoleNameOrDefault(consoleName string) string {
+	if consoleName == "" {
+		return DefaultConsoleName
+	}
+	return consoleName
+}
+
+// CloseConsoleManager closes the console manager and its underlying HTTP connections
+func (cm *ZOSMFConsoleManager) CloseConsoleManager() error {
+	session := cm.session
+	if session == nil {
+		return nil
+	}
+
+	// Close idle connections in the HTTP client
+	if client := session.GetHTTPClient(); client != nil {
+		client.CloseIdleConnections()
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package console
+
+import "github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+
+// IssueRequest represents the body of a console command request
+type IssueRequest struct {
+	Command  string `json:"cmd"`                 // Command to issue
+	SolKey   string `json:"sol-key,omitempty"`   // Keyword to wait for in the solicited response
+	UnsolKey string `json:"unsol-key,omitempty"` // Keyword to wait for in unsolicited messages
+	System   string `json:"system,omitempty"`    // System in the sysplex to route the command to
+	Async    string `json:"async,omitempty"`     // "Y" returns immediately with a response key
+}
+
+// ConsoleResponse represents the z/OSMF response to a console command
+type ConsoleResponse struct {
+	CommandResponse    string `json:"cmd-response"`               // Solicited response text
+	CommandResponseKey string `json:"cmd-response-key,omitempty"` // Key for GetResponse follow-ups
+	CommandResponseURL string `json:"cmd-response-url,omitempty"` // URL of the follow-up resource
+	CommandResponseURI string `json:"cmd-response-uri,omitempty"` // URI of the follow-up resource
+	SolKeyDetected     bool   `json:"sol-key-detected,omitempty"` // Whether the sol-key was found
+	Status             string `json:"status,omitempty"`           // Status of an async follow-up
+}
+
+// ConsoleManager interface for MVS console operations
+type ConsoleManager interface {
+	IssueCommand(consoleName, command string) (*ConsoleResponse, error)
+	IssueCommandWithOptions(consoleName string, request *IssueRequest) (*ConsoleResponse, error)
+	GetResponse(consoleName, responseKey string) (*ConsoleResponse, error)
+}
+
+// ZOSMFConsoleManager implements ConsoleManager for ZOSMF
+type ZOSMFConsoleManager struct {
+	session *profile.Session
+
+	// StrictJSON decodes responses in profile.DecodeJSON's strict mode
+	StrictJSON bool
+}
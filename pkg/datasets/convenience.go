@@ -1,11 +1,19 @@
 package datasets
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/jobs"
 	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
 )
 
@@ -107,10 +115,67 @@ func (dm *ZOSMFDatasetManager) UploadText(datasetName, content string) error {
 	return dm.UploadContent(request)
 }
 
+// AppendContent appends text to a sequential dataset, or to a member when
+// memberName is set. z/OSMF has no append write, so the current content is
+// read with its ETag and rewritten with If-Match; a concurrent change makes
+// the write fail with ErrETagMismatch and the read-modify-write is retried.
+func (dm *ZOSMFDatasetManager) AppendContent(datasetName, memberName, content string) error {
+	const maxAttempts = 3
+
+	var lastError error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err := dm.appendOnce(datasetName, memberName, content)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrETagMismatch) {
+			return err
+		}
+		lastError = err
+	}
+
+	return fmt.Errorf("append failed after %d attempts, content kept changing: %w", maxAttempts, lastError)
+}
+
+// appendOnce performs a single read-modify-write for AppendContent
+func (dm *ZOSMFDatasetManager) appendOnce(datasetName, memberName, content string) error {
+	existing, etag, err := dm.DownloadWithETag(&DownloadRequest{DatasetName: datasetName, MemberName: memberName})
+	if err != nil {
+		return err
+	}
+
+	// Keep the appended text on its own record
+	combined := existing
+	if combined != "" && !strings.HasSuffix(combined, "\n") {
+		combined += "\n"
+	}
+	combined += content
+
+	return dm.UploadWithETag(&UploadRequest{
+		DatasetName: datasetName,
+		MemberName:  memberName,
+		Content:     combined,
+		Replace:     true,
+	}, etag)
+}
+
+// UploadWithETag uploads content only if it still matches etag, as returned
+// by DownloadWithETag. A stale ETag fails with ErrETagMismatch.
+func (dm *ZOSMFDatasetManager) UploadWithETag(request *UploadRequest, etag string) error {
+	conditional := *request
+	conditional.IfMatch = etag
+	return dm.UploadContent(&conditional)
+}
+
 // UploadTextToMember uploads text content to a member in a partitioned dataset
 func (dm *ZOSMFDatasetManager) UploadTextToMember(datasetName, memberName, content string) error {
 	// Basic validation
-	if err := ValidateMemberName(memberName); err != nil {
+	datasetName, err := NormalizeDatasetName(datasetName)
+	if err != nil {
+		return fmt.Errorf("invalid dataset name: %w", err)
+	}
+	memberName, err = NormalizeMemberName(memberName)
+	if err != nil {
 		return fmt.Errorf("invalid member name: %w", err)
 	}
 
@@ -124,7 +189,7 @@ func (dm *ZOSMFDatasetManager) UploadTextToMember(datasetName, memberName, conte
 	}
 
 	// Try the upload with enhanced error handling
-	err := dm.UploadContent(request)
+	err = dm.UploadContent(request)
 	if err != nil {
 		// Provide specific guidance for common PDS errors
 		if strings.Contains(err.Error(), "ISRZ002") || strings.Contains(err.Error(), "I/O error") {
@@ -139,6 +204,75 @@ func (dm *ZOSMFDatasetManager) UploadTextToMember(datasetName, memberName, conte
 	return nil
 }
 
+// UploadMembers uploads several members of a partitioned dataset in
+// parallel, keyed by member name. Names are normalized and validated before
+// anything is sent. A member that fails doesn't stop the rest; the returned
+// map holds an error for each failed member, keyed by the name as given, and
+// the error joins them all. Both are empty when every upload succeeded.
+func (dm *ZOSMFDatasetManager) UploadMembers(datasetName string, members map[string]string) (map[string]error, error) {
+	datasetName, err := NormalizeDatasetName(datasetName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset name: %w", err)
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failed := make(map[string]error)
+	normalized := make(map[string]string)
+	var valid []string
+	for _, name := range names {
+		member, err := NormalizeMemberName(name)
+		if err != nil {
+			failed[name] = fmt.Errorf("invalid member name: %w", err)
+			continue
+		}
+		// "mem1" and "MEM1" are the same member; uploading both would race
+		if other, ok := normalized[member]; ok {
+			failed[name] = fmt.Errorf("member %s is also given as %s", member, other)
+			continue
+		}
+		normalized[member] = name
+		valid = append(valid, name)
+	}
+
+	var mu sync.Mutex
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for w := 0; w < uploadMembersConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range queue {
+				if err := dm.UploadTextToMember(datasetName, name, members[name]); err != nil {
+					mu.Lock()
+					failed[name] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, name := range valid {
+		queue <- name
+	}
+	close(queue)
+	wg.Wait()
+
+	var errs []error
+	for _, name := range names {
+		if err, ok := failed[name]; ok {
+			errs = append(errs, fmt.Errorf("member %s: %w", name, err))
+		}
+	}
+	return failed, errors.Join(errs...)
+}
+
+// uploadMembersConcurrency is how many members UploadMembers sends at once
+const uploadMembersConcurrency = 4
+
 // UploadTextToMemberWithValidation uploads text content to a member with comprehensive validation and retry logic
 func (dm *ZOSMFDatasetManager) UploadTextToMemberWithValidation(datasetName, memberName, content string) error {
 	// First, validate the member name according to z/OS standards
@@ -210,6 +344,31 @@ func (dm *ZOSMFDatasetManager) UploadTextToMemberWithValidation(datasetName, mem
 	return nil
 }
 
+// UploadBinary uploads raw bytes to a dataset or member without any
+// codepage conversion or newline handling. Leave memberName empty for
+// sequential datasets.
+func (dm *ZOSMFDatasetManager) UploadBinary(datasetName, memberName string, data []byte) error {
+	request := &UploadRequest{
+		DatasetName: datasetName,
+		MemberName:  memberName,
+		Data:        data,
+		DataType:    DataTypeBinary,
+		Replace:     true,
+	}
+	return dm.UploadContent(request)
+}
+
+// DownloadBinary downloads the raw bytes of a dataset or member.
+// Leave memberName empty for sequential datasets.
+func (dm *ZOSMFDatasetManager) DownloadBinary(datasetName, memberName string) ([]byte, error) {
+	request := &DownloadRequest{
+		DatasetName: datasetName,
+		MemberName:  memberName,
+		DataType:    DataTypeBinary,
+	}
+	return dm.downloadBytes(request)
+}
+
 // DownloadText downloads text content from a dataset
 func (dm *ZOSMFDatasetManager) DownloadText(datasetName string) (string, error) {
 	request := &DownloadRequest{
@@ -229,6 +388,385 @@ func (dm *ZOSMFDatasetManager) DownloadTextFromMember(datasetName, memberName st
 	return dm.DownloadContent(request)
 }
 
+// DownloadAllMembers downloads every member of a partitioned dataset as
+// text into targetDir/<member>, returning how many members were written
+func (dm *ZOSMFDatasetManager) DownloadAllMembers(datasetName, targetDir string) (int, error) {
+	return dm.DownloadAllMembersWithOptions(datasetName, targetDir, nil)
+}
+
+// DownloadAllMembersWithOptions downloads every member of a partitioned
+// dataset into targetDir, creating it if needed. A member that fails
+// doesn't stop the rest; the error joins one failure per member, and the
+// count covers the members that were written. opts may be nil.
+func (dm *ZOSMFDatasetManager) DownloadAllMembersWithOptions(datasetName, targetDir string, opts *DownloadMembersOptions) (int, error) {
+	o := DownloadMembersOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.DataType == "" {
+		o.DataType = DataTypeText
+	}
+	if o.DataType != DataTypeText && o.DataType != DataTypeBinary {
+		return 0, fmt.Errorf("unsupported data type %q for member download", o.DataType)
+	}
+	if o.MaxConcurrency <= 0 {
+		o.MaxConcurrency = 4
+	}
+	extension := o.Extension
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	memberList, err := dm.ListMembers(datasetName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list members: %w", err)
+	}
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	errs := make([]error, len(memberList.Members))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < o.MaxConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				name := memberList.Members[i].Name
+				path := filepath.Join(targetDir, name+extension)
+				if err := dm.downloadMemberToFile(datasetName, name, o.DataType, path); err != nil {
+					errs[i] = fmt.Errorf("member %s: %w", name, err)
+				}
+			}
+		}()
+	}
+	for i := range memberList.Members {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	downloaded := 0
+	for _, err := range errs {
+		if err == nil {
+			downloaded++
+		}
+	}
+	return downloaded, errors.Join(errs...)
+}
+
+// downloadMemberToFile streams one member into the file at path, removing
+// the partial file if the download fails
+func (dm *ZOSMFDatasetManager) downloadMemberToFile(datasetName, memberName string, dataType DataType, path string) error {
+	request := &DownloadRequest{
+		DatasetName: datasetName,
+		MemberName:  memberName,
+		DataType:    dataType,
+	}
+	if dataType == DataTypeText {
+		request.Encoding = "UTF-8"
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	_, err = dm.DownloadContentTo(request, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// ReadMemberLines reads count lines of a member starting at the zero-based
+// line start, using a record-range read so only that window is transferred
+func (dm *ZOSMFDatasetManager) ReadMemberLines(datasetName, memberName string, start, count int) ([]string, error) {
+	if start < 0 {
+		return nil, fmt.Errorf("start line cannot be negative")
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("line count must be greater than 0")
+	}
+
+	request := &DownloadRequest{
+		DatasetName: datasetName,
+		MemberName:  memberName,
+		StartRecord: start,
+		RecordCount: count,
+	}
+	content, err := dm.DownloadContent(request)
+	if err != nil {
+		return nil, err
+	}
+
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return []string{}, nil
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) > count {
+		lines = lines[:count]
+	}
+	return lines, nil
+}
+
+// maxLineLength bounds one line read by ForEachLine; z/OS records are at
+// most 32760 bytes, so this leaves room for multi-byte conversions
+const maxLineLength = 1 << 20
+
+// DownloadLines downloads a sequential dataset as text and returns one entry
+// per record, with LF or CRLF line ends removed and no empty entry for a
+// trailing newline
+func (dm *ZOSMFDatasetManager) DownloadLines(datasetName string) ([]string, error) {
+	lines := []string{}
+	err := dm.ForEachLine(datasetName, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// ForEachLine streams a sequential dataset as text and calls fn for each
+// record, split as DownloadLines does. The content is never held in memory
+// as a whole. An error from fn stops the download and is returned as is.
+func (dm *ZOSMFDatasetManager) ForEachLine(datasetName string, fn func(line string) error) error {
+	resp, err := dm.openDownload(&DownloadRequest{DatasetName: datasetName}, false)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// ScanLines drops the \r of a CRLF along with the \n
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		if err := fn(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	return nil
+}
+
+// IsMigrated reports whether HSM has migrated a dataset, based on the
+// migr attribute (or the MIGRAT volser on older systems) from the list API
+func (dm *ZOSMFDatasetManager) IsMigrated(name string) (bool, error) {
+	dataset, err := dm.GetDataset(name)
+	if err != nil {
+		return false, err
+	}
+	return isMigrated(dataset), nil
+}
+
+// isMigrated checks the list attributes HSM migration shows up in
+func isMigrated(dataset *Dataset) bool {
+	return strings.EqualFold(dataset.Migrated, "YES") || strings.EqualFold(dataset.Volume, "MIGRAT")
+}
+
+// DownloadTextEnsureRecalled downloads a dataset as text, first recalling it
+// from HSM and waiting for the recall when it has been migrated. A recall
+// that doesn't finish within RecallTimeout fails with ErrRecallTimeout.
+func (dm *ZOSMFDatasetManager) DownloadTextEnsureRecalled(datasetName string) (string, error) {
+	migrated, err := dm.IsMigrated(datasetName)
+	if err != nil {
+		return "", fmt.Errorf("failed to check migration status: %w", err)
+	}
+
+	if migrated {
+		timeout := dm.RecallTimeout
+		if timeout <= 0 {
+			timeout = DefaultRecallTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := dm.recallDataset(ctx, datasetName, true)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("%w: %s not recalled within %v", ErrRecallTimeout, datasetName, timeout)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to recall dataset: %w", err)
+		}
+
+		// The wait can end with the recall still queued behind other HSM work
+		migrated, err = dm.IsMigrated(datasetName)
+		if err != nil {
+			return "", fmt.Errorf("failed to check migration status: %w", err)
+		}
+		if migrated {
+			return "", fmt.Errorf("%w: %s is still migrated", ErrRecallTimeout, datasetName)
+		}
+	}
+
+	return dm.DownloadText(datasetName)
+}
+
+// SearchMembers searches every member of a partitioned dataset for pattern.
+// Only members with matches or read errors are returned, in member-list
+// order. A member that fails to download is reported through its Err field
+// instead of aborting the search.
+func (dm *ZOSMFDatasetManager) SearchMembers(datasetName, pattern string, opts SearchOptions) ([]MemberMatch, error) {
+	matcher, err := newLineMatcher(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	memberList, err := dm.ListMembers(datasetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	workers := opts.MaxConcurrency
+	if workers <= 0 {
+		workers = 4
+	}
+
+	results := make([]MemberMatch, len(memberList.Members))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				name := memberList.Members[i].Name
+				results[i] = MemberMatch{Member: name}
+				content, err := dm.DownloadTextFromMember(datasetName, name)
+				if err != nil {
+					results[i].Err = err
+					continue
+				}
+				for n, line := range strings.Split(content, "\n") {
+					if matcher(line) {
+						results[i].Matches = append(results[i].Matches, LineMatch{Line: n + 1, Text: line})
+					}
+				}
+			}
+		}()
+	}
+	for i := range memberList.Members {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	var found []MemberMatch
+	for _, result := range results {
+		if result.Err != nil || len(result.Matches) > 0 {
+			found = append(found, result)
+		}
+	}
+	return found, nil
+}
+
+// newLineMatcher builds the line predicate for SearchMembers
+func newLineMatcher(pattern string, opts SearchOptions) (func(string) bool, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern cannot be empty")
+	}
+
+	if opts.Regex {
+		if opts.CaseInsensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search pattern: %w", err)
+		}
+		return re.MatchString, nil
+	}
+
+	if opts.CaseInsensitive {
+		lower := strings.ToLower(pattern)
+		return func(line string) bool {
+			return strings.Contains(strings.ToLower(line), lower)
+		}, nil
+	}
+	return func(line string) bool {
+		return strings.Contains(line, pattern)
+	}, nil
+}
+
+// ListDatasetsPages lists datasets a page at a time, following z/OSMF's
+// moreRows indicator by restarting from the last name returned. fn is called
+// for each page and can return false to stop early. filter.Limit sets the
+// page size (default DefaultListPageSize) and filter.MaxResults caps the
+// total number of datasets delivered.
+func (dm *ZOSMFDatasetManager) ListDatasetsPages(filter *DatasetFilter, fn func(page *DatasetList) bool) error {
+	pageFilter := DatasetFilter{}
+	if filter != nil {
+		pageFilter = *filter
+	}
+	if pageFilter.Limit <= 0 {
+		pageFilter.Limit = DefaultListPageSize
+	}
+
+	delivered := 0
+	lastName := ""
+	for {
+		page, err := dm.ListDatasets(&pageFilter)
+		if err != nil {
+			return err
+		}
+
+		// start is inclusive, so the previous page's last name comes back first
+		if lastName != "" && len(page.Datasets) > 0 && page.Datasets[0].Name == lastName {
+			page.Datasets = page.Datasets[1:]
+			page.ReturnedRows = len(page.Datasets)
+		}
+		if pageFilter.MaxResults > 0 && delivered+len(page.Datasets) >= pageFilter.MaxResults {
+			capped := pageFilter.MaxResults - delivered
+			more := page.MoreRows || capped < len(page.Datasets)
+			page.Datasets = page.Datasets[:capped]
+			page.ReturnedRows = capped
+			page.MoreRows = more
+			page.Truncated = more
+			fn(page)
+			return nil
+		}
+
+		delivered += len(page.Datasets)
+		if !fn(page) || !page.MoreRows {
+			return nil
+		}
+
+		// Type filtering can empty a page, so continue from the raw listing
+		if page.lastName == "" || page.lastName == lastName {
+			return fmt.Errorf("dataset listing stopped advancing after %q", lastName)
+		}
+		lastName = page.lastName
+		pageFilter.Start = lastName
+	}
+}
+
+// ListDatasetsAll lists every dataset matching filter across all pages.
+// Truncated is set on the result when filter.MaxResults stopped the listing.
+func (dm *ZOSMFDatasetManager) ListDatasetsAll(filter *DatasetFilter) (*DatasetList, error) {
+	all := &DatasetList{}
+	err := dm.ListDatasetsPages(filter, func(page *DatasetList) bool {
+		all.Datasets = append(all.Datasets, page.Datasets...)
+		all.JSONVersion = page.JSONVersion
+		all.Truncated = page.Truncated
+		all.MoreRows = page.Truncated
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	all.ReturnedRows = len(all.Datasets)
+
+	return all, nil
+}
+
 // GetDatasetsByOwner gets datasets owned by a specific user
 // Note: z/OSMF API doesn't support owner filtering directly, so we use name pattern
 func (dm *ZOSMFDatasetManager) GetDatasetsByOwner(owner string, limit int) (*DatasetList, error) {
@@ -240,7 +778,22 @@ func (dm *ZOSMFDatasetManager) GetDatasetsByOwner(owner string, limit int) (*Dat
 	return dm.ListDatasets(filter)
 }
 
-// GetDatasetsByType gets datasets of a specific type
+// GetDatasetsByVolume gets the datasets on a volume. Only volser is sent,
+// so the listing isn't narrowed to the user's own high-level qualifier.
+func (dm *ZOSMFDatasetManager) GetDatasetsByVolume(volume string, limit int) (*DatasetList, error) {
+	volume = strings.ToUpper(strings.TrimSpace(volume))
+	if volume == "" || len(volume) > 6 {
+		return nil, fmt.Errorf("invalid volume serial %q", volume)
+	}
+	filter := &DatasetFilter{
+		Volume: volume,
+		Limit:  limit,
+	}
+	return dm.ListDatasets(filter)
+}
+
+// GetDatasetsByType gets datasets of a specific type.
+// Filtering happens client-side, so limit caps the rows fetched before filtering.
 func (dm *ZOSMFDatasetManager) GetDatasetsByType(datasetType string, limit int) (*DatasetList, error) {
 	filter := &DatasetFilter{
 		Type:  datasetType,
@@ -249,6 +802,69 @@ func (dm *ZOSMFDatasetManager) GetDatasetsByType(datasetType string, limit int)
 	return dm.ListDatasets(filter)
 }
 
+// filterDatasetsByType keeps only datasets whose dsorg matches the requested type
+func filterDatasetsByType(list *DatasetList, datasetType string) {
+	filtered := make([]Dataset, 0, len(list.Datasets))
+	for _, ds := range list.Datasets {
+		if matchesDatasetType(ds.Type, datasetType) {
+			filtered = append(filtered, ds)
+		}
+	}
+	list.Datasets = filtered
+	list.ReturnedRows = len(filtered)
+}
+
+// matchesDatasetType compares a z/OSMF dsorg value against a DatasetType
+func matchesDatasetType(dsorg, datasetType string) bool {
+	dsorg = strings.ToUpper(dsorg)
+	switch DatasetType(strings.ToUpper(datasetType)) {
+	case DatasetTypeSequential:
+		return dsorg == "PS"
+	case DatasetTypePartitioned:
+		return dsorg == "PO"
+	case DatasetTypePDSE:
+		return dsorg == "PO-E"
+	case DatasetTypeVSAM:
+		return dsorg == "VS" || dsorg == "VSAM"
+	default:
+		return dsorg == strings.ToUpper(datasetType)
+	}
+}
+
+// ListDatasetsNotReferencedSince lists datasets matching pattern whose last
+// reference date is before cutoff. Datasets without a usable reference date
+// are left out since their age can't be judged.
+func (dm *ZOSMFDatasetManager) ListDatasetsNotReferencedSince(pattern string, cutoff time.Time) ([]Dataset, error) {
+	list, err := dm.ListDatasetsAll(&DatasetFilter{Name: pattern})
+	if err != nil {
+		return nil, err
+	}
+
+	stale := make([]Dataset, 0, len(list.Datasets))
+	for _, ds := range list.Datasets {
+		if !ds.Referenced.IsZero() && ds.Referenced.Before(cutoff) {
+			stale = append(stale, ds)
+		}
+	}
+	return stale, nil
+}
+
+// referencedDateLayouts are the rdate formats z/OSMF has been seen to return
+var referencedDateLayouts = []string{"2006/01/02", "2006-01-02"}
+
+// populateReferenced parses each dataset's RefDate into Referenced
+func populateReferenced(list *DatasetList) {
+	for i := range list.Datasets {
+		ds := &list.Datasets[i]
+		for _, layout := range referencedDateLayouts {
+			if t, err := time.Parse(layout, ds.RefDate); err == nil {
+				ds.Referenced = t
+				break
+			}
+		}
+	}
+}
+
 // GetDatasetsByName gets datasets matching a name pattern
 func (dm *ZOSMFDatasetManager) GetDatasetsByName(namePattern string, limit int) (*DatasetList, error) {
 	filter := &DatasetFilter{
@@ -258,7 +874,9 @@ func (dm *ZOSMFDatasetManager) GetDatasetsByName(namePattern string, limit int)
 	return dm.ListDatasets(filter)
 }
 
-// ValidateDatasetName validates a dataset name according to z/OS naming conventions
+// ValidateDatasetName validates a dataset name according to z/OS naming conventions:
+// at most 44 characters of period-separated qualifiers, each 1-8 characters
+// starting with a letter or national character (@, #, $)
 func ValidateDatasetName(name string) error {
 	if name == "" {
 		return fmt.Errorf("dataset name cannot be empty")
@@ -269,10 +887,9 @@ func ValidateDatasetName(name string) error {
 		return fmt.Errorf("dataset name cannot exceed 44 characters")
 	}
 
-	// Check for valid characters (A-Z, 0-9, @, #, $, -, .)
-	validPattern := regexp.MustCompile(`^[A-Z@#$][A-Z0-9@#$.-]*$`)
-	if !validPattern.MatchString(name) {
-		return fmt.Errorf("dataset name contains invalid characters")
+	// Check for leading/trailing periods
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return fmt.Errorf("dataset name cannot start or end with a period")
 	}
 
 	// Check for consecutive periods
@@ -280,19 +897,103 @@ func ValidateDatasetName(name string) error {
 		return fmt.Errorf("dataset name cannot contain consecutive periods")
 	}
 
-	// Check for leading/trailing periods
-	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
-		return fmt.Errorf("dataset name cannot start or end with a period")
-	}
-
 	// Check for consecutive hyphens
 	if strings.Contains(name, "--") {
 		return fmt.Errorf("dataset name cannot contain consecutive hyphens")
 	}
 
+	// Check each qualifier (A-Z, 0-9, @, #, $, -, not starting with a digit or hyphen)
+	for _, qualifier := range strings.Split(name, ".") {
+		if len(qualifier) > 8 {
+			return fmt.Errorf("dataset name qualifier %q exceeds 8 characters", qualifier)
+		}
+		if !qualifierPattern.MatchString(qualifier) {
+			if qualifier[0] >= '0' && qualifier[0] <= '9' || qualifier[0] == '-' {
+				return fmt.Errorf("dataset name qualifier %q must start with a letter or @, # or $", qualifier)
+			}
+			return fmt.Errorf("dataset name contains invalid characters")
+		}
+	}
+
 	return nil
 }
 
+// NormalizeDatasetName trims and uppercases a dataset name, then validates
+// it, so "myuser.test" becomes "MYUSER.TEST". Invalid characters are still
+// rejected.
+func NormalizeDatasetName(name string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if err := ValidateDatasetName(normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+// NormalizeMemberName trims and uppercases a member name, then validates it
+func NormalizeMemberName(name string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if err := ValidateMemberName(normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+// qualifierPattern matches one dataset name qualifier
+var qualifierPattern = regexp.MustCompile(`^[A-Z@#$][A-Z0-9@#$-]*$`)
+
+// ParseFilter turns a user-typed pattern such as "SYS1.*.LOAD", "*.LISTING"
+// or 'USER.**' into a DatasetFilter with the matching dslevel. The pattern is
+// uppercased and TSO-style quotes are dropped. In a qualifier, * matches any
+// characters and % a single character; ** on its own matches any number of
+// qualifiers. Patterns z/OSMF can't search are rejected.
+func ParseFilter(pattern string) (*DatasetFilter, error) {
+	dslevel := strings.ToUpper(strings.TrimSpace(pattern))
+	dslevel = strings.TrimSuffix(strings.TrimPrefix(dslevel, "'"), "'")
+	if dslevel == "" {
+		return nil, fmt.Errorf("dataset pattern cannot be empty")
+	}
+	if len(dslevel) > 44 {
+		return nil, fmt.Errorf("dataset pattern %q exceeds 44 characters", dslevel)
+	}
+
+	qualifiers := strings.Split(dslevel, ".")
+	literal := false
+	for i, qualifier := range qualifiers {
+		if qualifier == "" {
+			return nil, fmt.Errorf("dataset pattern %q has an empty qualifier", dslevel)
+		}
+		if qualifier == "**" {
+			if i > 0 && qualifiers[i-1] == "**" {
+				return nil, fmt.Errorf("dataset pattern %q repeats **", dslevel)
+			}
+			continue
+		}
+		if strings.Contains(qualifier, "**") {
+			return nil, fmt.Errorf("** must be a whole qualifier in dataset pattern %q", dslevel)
+		}
+		if !filterQualifierPattern.MatchString(qualifier) {
+			return nil, fmt.Errorf("invalid qualifier %q in dataset pattern %q", qualifier, dslevel)
+		}
+		if len(strings.ReplaceAll(qualifier, "*", "")) > 8 {
+			return nil, fmt.Errorf("qualifier %q in dataset pattern %q exceeds 8 characters", qualifier, dslevel)
+		}
+		if strings.Trim(qualifier, "*%") != "" {
+			literal = true
+		}
+	}
+
+	// All-wildcard patterns would walk the entire catalog
+	if !literal {
+		return nil, fmt.Errorf("dataset pattern %q needs at least one qualifier that isn't only wildcards", dslevel)
+	}
+
+	return &DatasetFilter{Name: dslevel}, nil
+}
+
+// filterQualifierPattern matches one dslevel qualifier, wildcards included.
+// Like dataset names, a qualifier can't start with a digit or hyphen.
+var filterQualifierPattern = regexp.MustCompile(`^[A-Z@#$*%][A-Z0-9@#$*%-]*$`)
+
 // ValidateMemberName validates a member name according to z/OS naming conventions
 func ValidateMemberName(name string) error {
 	if name == "" {
@@ -323,6 +1024,11 @@ func ValidateMemberName(name string) error {
 	return nil
 }
 
+// recordFormatPattern matches the z/OS record formats z/OSMF allocates:
+// F or V, optionally blocked (B) and spanned or standard (S), or U, each
+// with an optional ASA (A) or machine (M) control character
+var recordFormatPattern = regexp.MustCompile(`^(?:[FV]B?S?|U)[AM]?$`)
+
 // ValidateCreateDatasetRequest validates a create dataset request
 func ValidateCreateDatasetRequest(request *CreateDatasetRequest) error {
 	if request == nil {
@@ -336,8 +1042,11 @@ func ValidateCreateDatasetRequest(request *CreateDatasetRequest) error {
 
 	// Validate dataset type
 	switch request.Type {
-	case DatasetTypeSequential, DatasetTypePartitioned, DatasetTypePDSE, DatasetTypeVSAM:
+	case DatasetTypeSequential, DatasetTypePartitioned, DatasetTypePDSE:
 		// Valid types
+	case DatasetTypeVSAM:
+		// The dataset create service only allocates non-VSAM datasets
+		return fmt.Errorf("VSAM clusters can't be allocated this way; use CreateVSAM")
 	default:
 		return fmt.Errorf("invalid dataset type: %s", request.Type)
 	}
@@ -359,13 +1068,8 @@ func ValidateCreateDatasetRequest(request *CreateDatasetRequest) error {
 	}
 
 	// Validate record format
-	if request.RecordFormat != "" {
-		switch request.RecordFormat {
-		case RecordFormatFixed, RecordFormatVariable, RecordFormatUndefined:
-			// Valid formats
-		default:
-			return fmt.Errorf("invalid record format: %s", request.RecordFormat)
-		}
+	if request.RecordFormat != "" && !recordFormatPattern.MatchString(string(request.RecordFormat)) {
+		return fmt.Errorf("invalid record format: %s", request.RecordFormat)
 	}
 
 	// Validate record length
@@ -392,6 +1096,102 @@ func ValidateCreateDatasetRequest(request *CreateDatasetRequest) error {
 	return nil
 }
 
+// ValidateCreateVSAMRequest checks a VSAM cluster definition before it is
+// sent to IDCAMS
+func ValidateCreateVSAMRequest(request *CreateVSAMRequest) error {
+	if request == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+
+	if err := ValidateDatasetName(strings.ToUpper(strings.TrimSpace(request.Name))); err != nil {
+		return fmt.Errorf("invalid dataset name: %w", err)
+	}
+
+	organization := request.Organization
+	if organization == "" {
+		organization = VSAMKSDS
+	}
+	switch organization {
+	case VSAMKSDS:
+		if request.KeyLength < 1 || request.KeyLength > 255 {
+			return fmt.Errorf("key length must be between 1 and 255")
+		}
+		if request.KeyOffset < 0 {
+			return fmt.Errorf("key offset cannot be negative")
+		}
+		if request.MaxRecordSize > 0 && request.KeyOffset+request.KeyLength > request.MaxRecordSize {
+			return fmt.Errorf("key (offset %d, length %d) doesn't fit in a %d byte record",
+				request.KeyOffset, request.KeyLength, request.MaxRecordSize)
+		}
+	case VSAMESDS, VSAMRRDS, VSAMLDS:
+		if request.KeyLength != 0 || request.KeyOffset != 0 {
+			return fmt.Errorf("keys are only allowed for %s clusters", VSAMKSDS)
+		}
+	default:
+		return fmt.Errorf("invalid VSAM organization: %s", request.Organization)
+	}
+
+	// Record sizes
+	if organization == VSAMLDS {
+		if request.AverageRecordSize != 0 || request.MaxRecordSize != 0 {
+			return fmt.Errorf("linear clusters have no record size")
+		}
+	} else {
+		if request.AverageRecordSize < 0 || request.MaxRecordSize < 0 {
+			return fmt.Errorf("record sizes cannot be negative")
+		}
+		if request.AverageRecordSize > 0 && request.MaxRecordSize == 0 {
+			return fmt.Errorf("average record size needs a maximum record size")
+		}
+		if request.MaxRecordSize > 32761 {
+			return fmt.Errorf("maximum record size must be at most 32761")
+		}
+		if request.AverageRecordSize > request.MaxRecordSize {
+			return fmt.Errorf("average record size %d exceeds maximum %d", request.AverageRecordSize, request.MaxRecordSize)
+		}
+		if organization == VSAMRRDS && request.AverageRecordSize > 0 && request.AverageRecordSize != request.MaxRecordSize {
+			return fmt.Errorf("%s records are fixed length; average and maximum size must match", VSAMRRDS)
+		}
+	}
+
+	// Control interval size: 512 byte steps up to 8K, then 2K steps up to 32K;
+	// linear clusters use 4K steps
+	if ci := request.CISize; ci != 0 {
+		switch {
+		case ci < 512 || ci > 32768:
+			return fmt.Errorf("CI size must be between 512 and 32768")
+		case organization == VSAMLDS && ci%4096 != 0:
+			return fmt.Errorf("CI size of a linear cluster must be a multiple of 4096")
+		case ci <= 8192 && ci%512 != 0:
+			return fmt.Errorf("CI size up to 8192 must be a multiple of 512")
+		case ci > 8192 && ci%2048 != 0:
+			return fmt.Errorf("CI size above 8192 must be a multiple of 2048")
+		}
+		if request.MaxRecordSize > ci-7 {
+			return fmt.Errorf("maximum record size %d doesn't fit a %d byte control interval", request.MaxRecordSize, ci)
+		}
+	}
+
+	// Space allocation
+	if request.Space.Primary <= 0 {
+		return fmt.Errorf("primary space allocation must be greater than 0")
+	}
+	if request.Space.Secondary < 0 {
+		return fmt.Errorf("secondary space allocation cannot be negative")
+	}
+	if _, ok := vsamSpaceUnits[request.Space.Unit]; !ok {
+		return fmt.Errorf("invalid space unit for VSAM: %s", request.Space.Unit)
+	}
+
+	for _, volume := range request.Volumes {
+		if volume == "" || len(volume) > 6 {
+			return fmt.Errorf("invalid volume serial %q", volume)
+		}
+	}
+
+	return nil
+}
+
 // ValidateUploadRequest validates an upload request
 func ValidateUploadRequest(request *UploadRequest) error {
 	if request == nil {
@@ -411,10 +1211,15 @@ func ValidateUploadRequest(request *UploadRequest) error {
 	}
 
 	// Validate content
-	if request.Content == "" {
+	if request.Content == "" && len(request.Data) == 0 {
 		return fmt.Errorf("content cannot be empty")
 	}
 
+	// Validate transfer mode
+	if err := validateDataType(request.DataType, request.Encoding); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -436,9 +1241,30 @@ func ValidateDownloadRequest(request *DownloadRequest) error {
 		}
 	}
 
+	// Validate transfer mode
+	if err := validateDataType(request.DataType, request.Encoding); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateDataType checks the transfer mode and rejects an encoding on
+// binary or record transfers, where z/OSMF does no codepage conversion
+func validateDataType(dataType DataType, encoding string) error {
+	switch dataType {
+	case "", DataTypeText:
+		return nil
+	case DataTypeBinary, DataTypeRecord:
+		if encoding != "" {
+			return fmt.Errorf("encoding %q cannot be used with %s data type; encodings only apply to text transfers", encoding, dataType)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid data type %q", dataType)
+	}
+}
+
 // CreateDefaultSpace creates a default space allocation
 func CreateDefaultSpace(unit SpaceUnit) Space {
 	return Space{
@@ -479,6 +1305,66 @@ func (dm *ZOSMFDatasetManager) CopyMemberWithSameName(sourceDataset, targetDatas
 	return dm.CopyMember(sourceDataset, memberName, targetDataset, memberName)
 }
 
+// CopyToMember copies a sequential dataset into a member of a partitioned dataset
+func (dm *ZOSMFDatasetManager) CopyToMember(sourceDataset, targetDataset, targetMember string) error {
+	targetMember, err := NormalizeMemberName(targetMember)
+	if err != nil {
+		return fmt.Errorf("invalid member name: %w", err)
+	}
+	if sourceDataset, err = dm.checkOrganization(sourceDataset, false); err != nil {
+		return err
+	}
+	if targetDataset, err = dm.checkOrganization(targetDataset, true); err != nil {
+		return err
+	}
+
+	return dm.copyDataset(targetDataset, targetMember, map[string]string{
+		"dsn": sourceDataset,
+	}, nil)
+}
+
+// CopyMemberToSequential copies a member of a partitioned dataset into a sequential dataset
+func (dm *ZOSMFDatasetManager) CopyMemberToSequential(sourceDataset, sourceMember, targetDataset string) error {
+	sourceMember, err := NormalizeMemberName(sourceMember)
+	if err != nil {
+		return fmt.Errorf("invalid member name: %w", err)
+	}
+	if sourceDataset, err = dm.checkOrganization(sourceDataset, true); err != nil {
+		return err
+	}
+	if targetDataset, err = dm.checkOrganization(targetDataset, false); err != nil {
+		return err
+	}
+
+	return dm.copyDataset(targetDataset, "", map[string]string{
+		"dsn":    sourceDataset,
+		"member": sourceMember,
+	}, nil)
+}
+
+// checkOrganization verifies a dataset is partitioned (PO/PO-E) or sequential
+// (PS) and returns its normalized name
+func (dm *ZOSMFDatasetManager) checkOrganization(name string, partitioned bool) (string, error) {
+	name, err := NormalizeDatasetName(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid dataset name: %w", err)
+	}
+
+	dsInfo, err := dm.GetDataset(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get dataset information: %w", err)
+	}
+
+	isPartitioned := dsInfo.Type == "PO" || dsInfo.Type == "PO-E"
+	if partitioned && !isPartitioned {
+		return "", fmt.Errorf("dataset %s is not a partitioned dataset (type: %s)", name, dsInfo.Type)
+	}
+	if !partitioned && dsInfo.Type != "PS" {
+		return "", fmt.Errorf("dataset %s is not a sequential dataset (type: %s)", name, dsInfo.Type)
+	}
+	return name, nil
+}
+
 // uploadWithRetry attempts to upload content with retry logic for PDS directory issues
 func (dm *ZOSMFDatasetManager) uploadWithRetry(request *UploadRequest, memberExists bool) error {
 	const maxRetries = 3
@@ -570,4 +1456,98 @@ func (dm *ZOSMFDatasetManager) CheckPDSDirectoryHealth(datasetName string) error
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// CompressDataset compresses a PDS in place to reclaim the space left by
+// deleted and replaced members. z/OSMF has no compress utility request, so
+// an IEBCOPY job is submitted and waited on with the default options.
+func (dm *ZOSMFDatasetManager) CompressDataset(name string) error {
+	_, err := dm.CompressDatasetWithOptions(name, nil)
+	return err
+}
+
+// CompressDatasetWithOptions compresses a PDS with an IEBCOPY job and returns
+// the job result, so callers can report the job and its condition code.
+// IEBCOPY ends with CC 0004 for warnings, which still counts as success.
+func (dm *ZOSMFDatasetManager) CompressDatasetWithOptions(name string, opts *CompressOptions) (*jobs.JobResult, error) {
+	if err := ValidateDatasetName(name); err != nil {
+		return nil, err
+	}
+
+	o := CompressOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.JobCard == "" {
+		o.JobCard = DefaultCompressJobCard
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Minute
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 2 * time.Second
+	}
+
+	// PDSEs reuse space on their own and IEBCOPY can't compress them
+	dsInfo, err := dm.GetDataset(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataset information: %w", err)
+	}
+	if dsInfo.Type != string(DatasetTypePartitioned) {
+		return nil, fmt.Errorf("dataset %s is not a PDS that can be compressed (type: %s)", name, dsInfo.Type)
+	}
+
+	jm := jobs.NewJobManager(dm.session)
+	submitted, err := jm.SubmitJobText(compressJCL(o.JobCard, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit compress job: %w", err)
+	}
+
+	result, err := jm.WaitForJobCompletion(submitted.JobName+":"+submitted.JobID, o.Timeout, o.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for compress job: %w", err)
+	}
+	if result.ConditionCode < 0 || result.ConditionCode > 4 {
+		return result, fmt.Errorf("compress job %s(%s) for %s failed: %s", result.JobName, result.JobID, name, result.RetCode)
+	}
+
+	return result, nil
+}
+
+// compressJCL builds an in-place IEBCOPY compress job for a PDS
+func compressJCL(jobCard, name string) string {
+	return strings.Join([]string{
+		jobCard,
+		"//COMPRESS EXEC PGM=IEBCOPY",
+		"//SYSPRINT DD SYSOUT=*",
+		"//PDS      DD DISP=OLD,DSN=" + strings.ToUpper(name),
+		"//SYSIN    DD *",
+		"  COPY OUTDD=PDS,INDD=PDS",
+		"/*",
+		"",
+	}, "\n")
+}
+
+// spaceAbendPattern matches x37 space abends as z/OS reports them, e.g.
+// "SB37-04" or "E37-0C", and their IEC030I/IEC031I/IEC032I messages
+var spaceAbendPattern = regexp.MustCompile(`\bS?[BDE]37-[0-9A-F]{2}\b|\bIEC03[012]I\b`)
+
+// IsPDSFull reports whether an upload failed because a PDS ran out of space
+// (x37 abends, z/OSMF "out of space" or "directory full" messages). Data
+// space can often be recovered with CompressDataset; a full directory needs
+// the dataset reallocated with more directory blocks.
+func IsPDSFull(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToUpper(err.Error())
+	if spaceAbendPattern.MatchString(msg) {
+		return true
+	}
+	for _, marker := range []string{"OUT OF SPACE", "DIRECTORY FULL", "NO SPACE IN DIRECTORY", "DIRECTORY IS FULL"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,19 @@
 package datasets
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +77,19 @@ func TestNewDatasetManagerFromProfile(t *testing.T) {
 	assert.NotNil(t, dm)
 }
 
+func TestNewDatasetManagerNilSession(t *testing.T) {
+	// A manager built without a session reports an error instead of panicking
+	dm := NewDatasetManager(nil)
+
+	_, err := dm.ListDatasets(nil)
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	err = dm.DeleteDataset("TEST.DATA")
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	assert.NoError(t, dm.CloseDatasetManager())
+}
+
 func TestCreateDatasetManager(t *testing.T) {
 	// Create a test profile manager
 	pm := &profile.ZOSMFProfileManager{}
@@ -131,6 +151,164 @@ func TestListDatasets(t *testing.T) {
 	assert.Equal(t, "TEST.DATA", datasetList.Datasets[0].Name)
 }
 
+func TestListTruncated(t *testing.T) {
+	moreRows := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/member") {
+			w.Write([]byte(fmt.Sprintf(`{"items":[{"member":"A"},{"member":"B"}],"returnedRows":2,"moreRows":%t,"JSONversion":1}`, moreRows)))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf(`{"items":[{"dsname":"TESTUSER.A"},{"dsname":"TESTUSER.B"}],"returnedRows":2,"moreRows":%t,"JSONversion":1}`, moreRows)))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	datasets, err := dm.ListDatasets(&DatasetFilter{Name: "TESTUSER.*", Limit: 2})
+	require.NoError(t, err)
+	assert.True(t, datasets.Truncated)
+
+	members, err := dm.ListMembers("TESTUSER.PDS")
+	require.NoError(t, err)
+	assert.True(t, members.Truncated)
+
+	moreRows = false
+	datasets, err = dm.ListDatasets(&DatasetFilter{Name: "TESTUSER.*"})
+	require.NoError(t, err)
+	assert.False(t, datasets.Truncated)
+
+	members, err = dm.ListMembers("TESTUSER.PDS")
+	require.NoError(t, err)
+	assert.False(t, members.Truncated)
+}
+
+func TestListDatasetsAttributes(t *testing.T) {
+	responses := map[string]string{
+		"base":   `{"items":[{"dsname":"USER.DATA","dsorg":"PS","vol":"VOL001","recfm":"FB","lrecl":"80","rdate":"2024/01/15"}],"returnedRows":1}`,
+		"vol":    `{"items":[{"dsname":"USER.DATA","vol":"VOL001"}],"returnedRows":1}`,
+		"dsname": `{"items":[{"dsname":"USER.DATA"}],"returnedRows":1}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.Header.Get("X-IBM-Attributes")]
+		require.True(t, ok, "unexpected attributes %q", r.Header.Get("X-IBM-Attributes"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Default is base: everything is filled in
+	list, err := dm.ListDatasets(&DatasetFilter{Name: "USER.*"})
+	require.NoError(t, err)
+	require.Len(t, list.Datasets, 1)
+	assert.Equal(t, "PS", list.Datasets[0].Type)
+	assert.Equal(t, "VOL001", list.Datasets[0].Volume)
+	assert.Equal(t, "FB", list.Datasets[0].RecordFormat)
+	assert.False(t, list.Datasets[0].Referenced.IsZero())
+
+	// vol: name and volume only
+	list, err = dm.ListDatasets(&DatasetFilter{Name: "USER.*", Attributes: AttributesVolume})
+	require.NoError(t, err)
+	require.Len(t, list.Datasets, 1)
+	assert.Equal(t, Dataset{Name: "USER.DATA", Volume: "VOL001"}, list.Datasets[0])
+
+	// dsname: name only
+	list, err = dm.ListDatasets(&DatasetFilter{Name: "USER.*", Attributes: AttributesName})
+	require.NoError(t, err)
+	require.Len(t, list.Datasets, 1)
+	assert.Equal(t, Dataset{Name: "USER.DATA"}, list.Datasets[0])
+
+	// Type filtering needs dsorg; unknown modes are rejected
+	_, err = dm.ListDatasets(&DatasetFilter{Name: "USER.*", Type: "PS", Attributes: AttributesVolume})
+	assert.ErrorContains(t, err, "needs base attributes")
+	_, err = dm.ListDatasets(&DatasetFilter{Name: "USER.*", Attributes: "everything"})
+	assert.ErrorContains(t, err, "invalid list attributes")
+}
+
+func TestListDatasetsStrictJSON(t *testing.T) {
+	// A field the SDK doesn't model yet
+	payload := `{"items":[{"dsname":"TEST.DATA","dsorg":"PS","newfield":"x"}],"returnedRows":1,"JSONversion":1}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Lenient by default
+	datasetList, err := dm.ListDatasets(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "TEST.DATA", datasetList.Datasets[0].Name)
+
+	// Strict mode reports the unknown field
+	dm.StrictJSON = true
+	_, err = dm.ListDatasets(nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "newfield")
+}
+
+func TestGetDatasetsByType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "base", r.Header.Get("X-IBM-Attributes"))
+		assert.Empty(t, r.URL.Query().Get("type"))
+
+		// Mixed result set as z/OSMF would return it
+		response := DatasetList{
+			Datasets: []Dataset{
+				{Name: "TESTUSER.SEQ", Type: "PS"},
+				{Name: "TESTUSER.PDS", Type: "PO"},
+				{Name: "TESTUSER.PDSE", Type: "PO-E"},
+				{Name: "TESTUSER.KSDS", Type: "VS"},
+				{Name: "TESTUSER.SEQ2", Type: "PS"},
+			},
+			ReturnedRows: 5,
+			JSONVersion:  1,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	tests := []struct {
+		datasetType DatasetType
+		want        []string
+	}{
+		{DatasetTypeSequential, []string{"TESTUSER.SEQ", "TESTUSER.SEQ2"}},
+		{DatasetTypePartitioned, []string{"TESTUSER.PDS"}},
+		{DatasetTypePDSE, []string{"TESTUSER.PDSE"}},
+		{DatasetTypeVSAM, []string{"TESTUSER.KSDS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.datasetType), func(t *testing.T) {
+			list, err := dm.GetDatasetsByType(string(tt.datasetType), 0)
+			require.NoError(t, err)
+
+			var names []string
+			for _, ds := range list.Datasets {
+				names = append(names, ds.Name)
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Equal(t, len(tt.want), list.ReturnedRows)
+		})
+	}
+}
+
 func TestGetDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -169,6 +347,48 @@ func TestGetDataset(t *testing.T) {
 	assert.Equal(t, "PS", dataset.Type)
 }
 
+func TestGetDatasetInfo(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Only the list API serves attributes; no content or metadata= calls
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds", r.URL.Path)
+		assert.Empty(t, r.URL.Query().Get("metadata"))
+		assert.Equal(t, "base", r.Header.Get("X-IBM-Attributes"))
+
+		// dslevel also matches datasets below the name
+		response := DatasetList{ReturnedRows: 2}
+		if r.URL.Query().Get("dslevel") == "TEST.DATA" {
+			response.Datasets = []Dataset{
+				{Name: "TEST.DATA", Type: "PS", RecordFormat: "FB", RecordLength: "80"},
+				{Name: "TEST.DATA.BACKUP", Type: "PS"},
+			}
+		} else {
+			response.Datasets = []Dataset{{Name: "TEST.OTHER.X", Type: "PS"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	dataset, err := dm.GetDatasetInfo("test.data")
+	require.NoError(t, err)
+	assert.Equal(t, "TEST.DATA", dataset.Name)
+	assert.Equal(t, "FB", dataset.RecordFormat)
+	assert.Equal(t, "80", dataset.RecordLength)
+	assert.Equal(t, 1, requests)
+
+	// Only an exact match counts
+	_, err = dm.GetDatasetInfo("TEST.OTHER")
+	assert.ErrorIs(t, err, ErrDatasetNotFound)
+	assert.Contains(t, err.Error(), "TEST.OTHER")
+}
+
 func TestCreateDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -211,6 +431,77 @@ func TestCreateDataset(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateDatasetWithResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.Header().Set("Location", "/api/v1/restfiles/ds/TEST.DATA")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	resp, err := dm.CreateDatasetWithResponse(&CreateDatasetRequest{
+		Name:         "test.data",
+		Type:         DatasetTypeSequential,
+		Space:        Space{Primary: 1, Unit: SpaceUnitTracks},
+		RecordFormat: RecordFormatFixed,
+		RecordLength: RecordLength80,
+		BlockSize:    BlockSize800,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", resp.Header.Get("Location"))
+}
+
+func TestCreateDatasetLike(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.NEW", r.URL.Path)
+		body = nil
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Model attributes only
+	err = dm.CreateDatasetLike("TEST.NEW", "TEST.MODEL", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"like": "TEST.MODEL"}, body)
+
+	// Space override
+	err = dm.CreateDatasetLike("TEST.NEW", "TEST.MODEL", &Space{Primary: 50, Secondary: 10, Unit: SpaceUnitCylinders})
+	require.NoError(t, err)
+	assert.Equal(t, "TEST.MODEL", body["like"])
+	assert.Equal(t, "CYL", body["alcunit"])
+	assert.Equal(t, float64(50), body["primary"])
+	assert.Equal(t, float64(10), body["secondary"])
+	_, hasDsorg := body["dsorg"]
+	assert.False(t, hasDsorg)
+
+	// Lowercase names are uppercased before they're sent
+	body = nil
+	err = dm.CreateDatasetLike("test.new", "test.model", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"like": "TEST.MODEL"}, body)
+
+	// Names are validated before any request
+	body = nil
+	err = dm.CreateDatasetLike("TEST.NEW", "", nil)
+	assert.Error(t, err)
+	err = dm.CreateDatasetLike("test.n@w!", "TEST.MODEL", nil)
+	assert.Error(t, err)
+	assert.Nil(t, body)
+}
+
 func TestDeleteDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -232,6 +523,181 @@ func TestDeleteDataset(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeleteDatasetOnVolume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/-(VOL001)/TEST.DATA", r.URL.Path)
+		assert.Equal(t, "/api/v1/restfiles/ds/-(VOL001)/TEST.DATA", r.URL.RequestURI())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.DeleteDatasetOnVolume("test.data", "vol001")
+	assert.NoError(t, err)
+}
+
+func TestDeleteDatasetInUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"category":4,"rc":8,"reason":0,"message":"Data set TEST.DATA in use by another user, try later"}`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.DeleteDataset("TEST.DATA")
+	assert.ErrorIs(t, err, ErrDatasetInUse)
+	var apiErr *profile.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+}
+
+func TestDeleteDatasetRecallMigrated(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method)
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items":[{"dsname":"TEST.DATA","migr":"YES","vol":"MIGRAT"}],"returnedRows":1}`))
+		case "PUT":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "hrecall", body["request"])
+			assert.Equal(t, true, body["wait"])
+			w.WriteHeader(http.StatusOK)
+		case "DELETE":
+			assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.DeleteDatasetWithOptions("TEST.DATA", &DeleteOptions{RecallMigrated: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET", "PUT", "DELETE"}, calls)
+}
+
+func TestDownloadTextEnsureRecalled(t *testing.T) {
+	tests := []struct {
+		name      string
+		migrated  []bool // Migration status reported by each listing, in order
+		recall    time.Duration
+		wantCalls []string
+		wantErr   error
+	}{
+		{"online", []bool{false}, 0, []string{"GET list", "GET content"}, nil},
+		{"recalled", []bool{true, false}, 0, []string{"GET list", "PUT", "GET list", "GET content"}, nil},
+		{"still migrated", []bool{true, true}, 0, []string{"GET list", "PUT", "GET list"}, ErrRecallTimeout},
+		{"timeout", []bool{true}, time.Second, []string{"GET list", "PUT"}, ErrRecallTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The timeout case returns while its recall handler is still running
+			var mu sync.Mutex
+			var calls []string
+			record := func(call string) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls = append(calls, call)
+			}
+			listings := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v1/restfiles/ds":
+					record("GET list")
+					migr := "NO"
+					if tt.migrated[listings] {
+						migr = "YES"
+					}
+					listings++
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"items":[{"dsname":"TEST.DATA","migr":%q}],"returnedRows":1}`, migr)
+				case r.Method == "PUT":
+					record("PUT")
+					var body map[string]interface{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, map[string]interface{}{"request": "hrecall", "wait": true}, body)
+					select {
+					case <-time.After(tt.recall):
+					case <-r.Context().Done():
+					}
+					w.WriteHeader(http.StatusOK)
+				default:
+					record("GET content")
+					w.Write([]byte("RECORD 1\n"))
+				}
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+			dm.RecallTimeout = 50 * time.Millisecond
+
+			content, err := dm.DownloadTextEnsureRecalled("TEST.DATA")
+			mu.Lock()
+			assert.Equal(t, tt.wantCalls, calls)
+			mu.Unlock()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "RECORD 1\n", content)
+		})
+	}
+}
+
+func TestDeleteDatasetIfExists(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantDeleted bool
+		wantErr     bool
+	}{
+		{"present", http.StatusNoContent, true, false},
+		{"absent", http.StatusNotFound, false, false},
+		{"error", http.StatusInternalServerError, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "DELETE", r.Method)
+				assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			profile := createTestProfile(server.URL)
+			session, err := profile.NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			deleted, err := dm.DeleteDatasetIfExists("TEST.DATA")
+			assert.Equal(t, tt.wantDeleted, deleted)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "API request failed with status 500")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestUploadContent(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -268,6 +734,131 @@ func TestUploadContent(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUploadContentDataType(t *testing.T) {
+	tests := []struct {
+		encoding string
+		want     string
+	}{
+		{"", "text"},
+		{"UTF-8", "text"},
+		{"IBM-1047", "text;fileEncoding=IBM-1047"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+				assert.Equal(t, tt.want, r.Header.Get("X-IBM-Data-Type"))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			profile := createTestProfile(server.URL)
+			session, err := profile.NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			err = dm.UploadContent(&UploadRequest{
+				DatasetName: "TEST.DATA",
+				Content:     "Hello, World!",
+				Encoding:    tt.encoding,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUploadContentNoReplace(t *testing.T) {
+	putCalled := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS/member", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("pattern") == "EXISTS" {
+				w.Write([]byte(`{"items":[{"member":"EXISTS"}],"returnedRows":1}`))
+			} else {
+				w.Write([]byte(`{"items":[],"returnedRows":0}`))
+			}
+			return
+		}
+		putCalled = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Existing member is refused
+	err = dm.UploadContent(&UploadRequest{DatasetName: "TEST.PDS", MemberName: "EXISTS", Content: "data"})
+	assert.ErrorIs(t, err, ErrMemberExists)
+	assert.False(t, putCalled)
+
+	// New member is created
+	err = dm.UploadContent(&UploadRequest{DatasetName: "TEST.PDS", MemberName: "NEWMEM", Content: "data"})
+	assert.NoError(t, err)
+	assert.True(t, putCalled)
+
+	// Replace skips the existence check entirely
+	putCalled = false
+	err = dm.UploadContent(&UploadRequest{DatasetName: "TEST.PDS", MemberName: "EXISTS", Content: "data", Replace: true})
+	assert.NoError(t, err)
+	assert.True(t, putCalled)
+}
+
+func TestUploadContentFrom(t *testing.T) {
+	content := strings.Repeat("LINE OF UPLOADED TEXT\n", 500)
+
+	var gotLength int64
+	var gotChunked bool
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		gotLength = r.ContentLength
+		gotChunked = len(r.TransferEncoding) > 0 && r.TransferEncoding[0] == "chunked"
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	request := &UploadRequest{DatasetName: "TEST.PDS", MemberName: "MEMBER1", Replace: true}
+
+	// From a temp file: length is known
+	path := filepath.Join(t.TempDir(), "upload.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	err = dm.UploadContentFrom(request, f)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), gotLength)
+	assert.False(t, gotChunked)
+	assert.Equal(t, content, gotBody)
+
+	// From an unsized reader: sent chunked
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte(content))
+		pw.Close()
+	}()
+	err = dm.UploadContentFrom(request, pr)
+	require.NoError(t, err)
+	assert.True(t, gotChunked)
+	assert.Equal(t, content, gotBody)
+}
+
 func TestDownloadContent(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -296,50 +887,296 @@ func TestDownloadContent(t *testing.T) {
 	assert.Equal(t, "Hello, World!", content)
 }
 
-func TestListMembers(t *testing.T) {
-	// Create test server
+func TestDownloadContentTo(t *testing.T) {
+	content := strings.Repeat("RECORD DATA LINE\n", 1000)
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
-		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS/member", r.URL.Path)
-		
-		// Return mock response matching z/OSMF API format
-		response := MemberList{
-			Members: []DatasetMember{
-				{
-					Name: "MEMBER1",
-				},
-			},
-			ReturnedRows: 1,
-			JSONVersion:  1,
-		}
-		
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.BIG", r.URL.Path)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(content))
 	}))
 	defer server.Close()
 
-	// Create dataset manager
 	profile := createTestProfile(server.URL)
 	session, err := profile.NewSession()
 	require.NoError(t, err)
 	dm := NewDatasetManager(session)
 
-	// Test list members
-	memberList, err := dm.ListMembers("TEST.PDS")
+	// Into a buffer
+	var buf bytes.Buffer
+	n, err := dm.DownloadContentTo(&DownloadRequest{DatasetName: "TEST.BIG"}, &buf)
 	require.NoError(t, err)
-	assert.Len(t, memberList.Members, 1)
-	assert.Equal(t, "MEMBER1", memberList.Members[0].Name)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, content, buf.String())
+
+	// Into a file
+	path := filepath.Join(t.TempDir(), "download.txt")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	n, err = dm.DownloadContentTo(&DownloadRequest{DatasetName: "TEST.BIG"}, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, int64(len(content)), n)
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written))
 }
 
-func TestGetMember(t *testing.T) {
-	// Create test server
+func TestDownloadContentToError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "GET", r.Method)
-		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
-		
-		// Return mock member content (z/OSMF returns member content as text)
-		w.Header().Set("Content-Type", "text/plain")
-		w.Write([]byte("Member content here"))
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Dataset not found"}`))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	var buf bytes.Buffer
+	n, err := dm.DownloadContentTo(&DownloadRequest{DatasetName: "TEST.MISSING"}, &buf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+	assert.Zero(t, n)
+	assert.Zero(t, buf.Len())
+}
+
+func TestReadMemberLines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(BIGMEM)", r.URL.Path)
+		assert.Equal(t, "200,3", r.Header.Get("X-IBM-Record-Range"))
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("LINE 200\nLINE 201\nLINE 202\n"))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	lines, err := dm.ReadMemberLines("TEST.PDS", "BIGMEM", 200, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LINE 200", "LINE 201", "LINE 202"}, lines)
+
+	_, err = dm.ReadMemberLines("TEST.PDS", "BIGMEM", -1, 3)
+	assert.Error(t, err)
+	_, err = dm.ReadMemberLines("TEST.PDS", "BIGMEM", 0, 0)
+	assert.Error(t, err)
+}
+
+func TestListMembers(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS/member", r.URL.Path)
+		
+		// Return mock response matching z/OSMF API format
+		response := MemberList{
+			Members: []DatasetMember{
+				{
+					Name: "MEMBER1",
+				},
+			},
+			ReturnedRows: 1,
+			JSONVersion:  1,
+		}
+		
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	// Create dataset manager
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Test list members
+	memberList, err := dm.ListMembers("TEST.PDS")
+	require.NoError(t, err)
+	assert.Len(t, memberList.Members, 1)
+	assert.Equal(t, "MEMBER1", memberList.Members[0].Name)
+}
+
+func TestListMembersZOSMFPayload(t *testing.T) {
+	// Captured z/OSMF member listing with ISPF statistics
+	payload := `{"items":[
+		{"member":"IEFBR14","vers":1,"mod":2,"c4date":"2023/05/01","m4date":"2023/05/02","cnorc":3,"inorc":3,"mnorc":0,"mtime":"10:15","msec":"42","user":"IBMUSER","sclm":"N"},
+		{"member":"NOSTATS"}
+	],"returnedRows":2,"moreRows":false,"JSONversion":1}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "base", r.Header.Get("X-IBM-Attributes"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	memberList, err := dm.ListMembers("TEST.PDS")
+	require.NoError(t, err)
+	require.Len(t, memberList.Members, 2)
+	assert.Equal(t, 2, memberList.ReturnedRows)
+
+	first := memberList.Members[0]
+	assert.Equal(t, "IEFBR14", first.Name)
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, 2, first.ModLevel)
+	assert.Equal(t, "2023/05/02", first.ModifiedDate)
+	assert.Equal(t, "IBMUSER", first.User)
+
+	second := memberList.Members[1]
+	assert.Equal(t, "NOSTATS", second.Name)
+	assert.Zero(t, second.Version)
+	assert.Empty(t, second.User)
+}
+
+func TestParseMemberListMixedAttributes(t *testing.T) {
+	// Attribute fields that don't match the expected types fall back to names only
+	dm := &ZOSMFDatasetManager{}
+	memberList, err := dm.parseMemberList([]byte(`{"items":[{"member":"A","vers":"x"},{"member":"B","vers":3}],"returnedRows":2}`))
+	require.NoError(t, err)
+	require.Len(t, memberList.Members, 2)
+	assert.Equal(t, "A", memberList.Members[0].Name)
+	assert.Zero(t, memberList.Members[0].Version)
+	assert.Equal(t, 3, memberList.Members[1].Version)
+	assert.Equal(t, 2, memberList.ReturnedRows)
+}
+
+func TestDownloadAllMembers(t *testing.T) {
+	contents := map[string]string{
+		"ALPHA": "//ALPHA JOB\n",
+		"BETA":  "//BETA JOB\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restfiles/ds/TEST.PDS/member" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items":[{"member":"ALPHA"},{"member":"BETA"},{"member":"BROKEN"}],"returnedRows":3}`))
+			return
+		}
+		for name, content := range contents {
+			if r.URL.Path == "/api/v1/restfiles/ds/TEST.PDS("+name+")" {
+				if r.Header.Get("X-IBM-Data-Type") == "binary" {
+					w.Write([]byte{0xC1, 0x00})
+					return
+				}
+				w.Write([]byte(content))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// The broken member is reported but the others still land on disk
+	dir := filepath.Join(t.TempDir(), "src")
+	count, err := dm.DownloadAllMembers("TEST.PDS", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "member BROKEN")
+	assert.Equal(t, 2, count)
+
+	data, err := os.ReadFile(filepath.Join(dir, "ALPHA"))
+	require.NoError(t, err)
+	assert.Equal(t, "//ALPHA JOB\n", string(data))
+	_, err = os.Stat(filepath.Join(dir, "BROKEN"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Binary mode with an extension
+	dir = t.TempDir()
+	count, err = dm.DownloadAllMembersWithOptions("TEST.PDS", dir, &DownloadMembersOptions{Extension: "bin", DataType: DataTypeBinary})
+	require.Error(t, err)
+	assert.Equal(t, 2, count)
+	data, err = os.ReadFile(filepath.Join(dir, "BETA.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xC1, 0x00}, data)
+
+	_, err = dm.DownloadAllMembersWithOptions("TEST.PDS", dir, &DownloadMembersOptions{DataType: DataTypeRecord})
+	assert.Error(t, err)
+}
+
+func TestSearchMembers(t *testing.T) {
+	contents := map[string]string{
+		"ALPHA": "//STEP1 EXEC PGM=IEFBR14\n//DD1 DD DSN=PROD.DATA,DISP=SHR\n",
+		"BETA":  "NO MATCH HERE\n",
+		"GAMMA": "prod.data lowercase\nsecond line\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restfiles/ds/TEST.PDS/member" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items":[{"member":"ALPHA"},{"member":"BETA"},{"member":"BROKEN"},{"member":"GAMMA"}],"returnedRows":4}`))
+			return
+		}
+		for name, content := range contents {
+			if r.URL.Path == "/api/v1/restfiles/ds/TEST.PDS("+name+")" {
+				w.Write([]byte(content))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Case-sensitive substring
+	results, err := dm.SearchMembers("TEST.PDS", "PROD.DATA", SearchOptions{MaxConcurrency: 2})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "ALPHA", results[0].Member)
+	assert.Equal(t, []LineMatch{{Line: 2, Text: "//DD1 DD DSN=PROD.DATA,DISP=SHR"}}, results[0].Matches)
+	assert.Equal(t, "BROKEN", results[1].Member)
+	assert.Error(t, results[1].Err)
+
+	// Case-insensitive substring picks up the lowercase member too
+	results, err = dm.SearchMembers("TEST.PDS", "prod.data", SearchOptions{CaseInsensitive: true})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "GAMMA", results[2].Member)
+	assert.Equal(t, 1, results[2].Matches[0].Line)
+
+	// Regex
+	results, err = dm.SearchMembers("TEST.PDS", `PGM=IEF\w+`, SearchOptions{Regex: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "ALPHA", results[0].Member)
+	assert.Equal(t, 1, results[0].Matches[0].Line)
+
+	// Bad inputs fail before any download
+	_, err = dm.SearchMembers("TEST.PDS", "", SearchOptions{})
+	assert.Error(t, err)
+	_, err = dm.SearchMembers("TEST.PDS", "(", SearchOptions{Regex: true})
+	assert.Error(t, err)
+}
+
+func TestGetMember(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
+		
+		// Return mock member content (z/OSMF returns member content as text)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("Member content here"))
 	}))
 	defer server.Close()
 
@@ -411,6 +1248,44 @@ func TestExists(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestExistsMinimalLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "vol", r.Header.Get("X-IBM-Attributes"))
+		assert.Equal(t, "1", r.Header.Get("X-IBM-Max-Items"))
+
+		response := DatasetList{}
+		switch r.URL.Query().Get("dslevel") {
+		case "TEST.DATA":
+			response.Datasets = []Dataset{{Name: "TEST.DATA", Volume: "VOL001"}}
+		case "TEST.PREFIX":
+			// Only datasets below the name exist
+			response.Datasets = []Dataset{{Name: "TEST.PREFIX.MORE", Volume: "VOL001"}}
+			response.MoreRows = true
+		}
+		response.ReturnedRows = len(response.Datasets)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	exists, err := dm.Exists("test.data")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dm.Exists("TEST.PREFIX")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// An empty result is a clean false
+	exists, err = dm.Exists("TEST.NOPE")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestCopySequentialDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -472,6 +1347,171 @@ func TestCopyMember(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCopyMemberHelpers(t *testing.T) {
+	var copyPath string
+	var copyBody map[string]interface{}
+	server := newCopyTestServer(t, &copyPath, &copyBody)
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Same dataset, new member name
+	err = dm.CopyMemberToSameDataset("test.pds", "member1", "backup1")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(BACKUP1)", copyPath)
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.PDS", "member": "MEMBER1"}, copyBody["from-dataset"])
+
+	// Across datasets, same member name
+	err = dm.CopyMemberWithSameName("TEST.PDS", "OTHER.PDS", "MEMBER1")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/OTHER.PDS(MEMBER1)", copyPath)
+	assert.Equal(t, "copy", copyBody["request"])
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.PDS", "member": "MEMBER1"}, copyBody["from-dataset"])
+
+	// Every name is validated before anything is sent
+	copyPath = ""
+	assert.Error(t, dm.CopyMember("BAD..PDS", "MEMBER1", "TEST.PDS", "MEMBER2"))
+	assert.Error(t, dm.CopyMember("TEST.PDS", "TOOLONGNAME", "TEST.PDS", "MEMBER2"))
+	assert.Error(t, dm.CopyMember("TEST.PDS", "MEMBER1", "1BAD.PDS", "MEMBER2"))
+	assert.Error(t, dm.CopyMember("TEST.PDS", "MEMBER1", "TEST.PDS", ""))
+	assert.Empty(t, copyPath)
+}
+
+// newCopyTestServer serves dataset listings for TEST.SEQ (PS) and TEST.PDS (PO)
+// and records the body of any copy request
+func newCopyTestServer(t *testing.T, copyPath *string, copyBody *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			dsorg := map[string]string{"TEST.SEQ": "PS", "TEST.PDS": "PO"}
+			name := r.URL.Query().Get("dslevel")
+			response := DatasetList{}
+			if org, ok := dsorg[name]; ok {
+				response.Datasets = []Dataset{{Name: name, Type: org}}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		assert.Equal(t, "PUT", r.Method)
+		*copyPath = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(copyBody))
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestCopyToMember(t *testing.T) {
+	var copyPath string
+	var copyBody map[string]interface{}
+	server := newCopyTestServer(t, &copyPath, &copyBody)
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.CopyToMember("TEST.SEQ", "TEST.PDS", "NEWMEM")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(NEWMEM)", copyPath)
+	assert.Equal(t, "copy", copyBody["request"])
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.SEQ"}, copyBody["from-dataset"])
+
+	// Lowercase names are uppercased
+	copyPath, copyBody = "", nil
+	err = dm.CopyToMember("test.seq", "test.pds", "newmem")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(NEWMEM)", copyPath)
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.SEQ"}, copyBody["from-dataset"])
+
+	// Organizations are checked before copying
+	copyPath = ""
+	err = dm.CopyToMember("TEST.PDS", "TEST.PDS", "NEWMEM")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not a sequential dataset")
+	err = dm.CopyToMember("TEST.SEQ", "TEST.SEQ", "NEWMEM")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not a partitioned dataset")
+	assert.Empty(t, copyPath)
+}
+
+func TestCopyDatasetWithOptions(t *testing.T) {
+	var copyPath string
+	var copyBody map[string]interface{}
+	server := newCopyTestServer(t, &copyPath, &copyBody)
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// A plain copy sends no options
+	err = dm.CopyDataset("TEST.SEQ", "TEST.SEQ2")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.SEQ2", copyPath)
+	assert.Equal(t, map[string]interface{}{"request": "copy", "from-dataset": map[string]interface{}{"dsn": "TEST.SEQ"}}, copyBody)
+
+	// Replace a whole PDS, aliases included
+	copyBody = nil
+	err = dm.CopyDatasetWithOptions("TEST.PDS", "OTHER.PDS", &CopyOptions{Replace: true, Enqueue: EnqueueExclusive, Alias: true})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/OTHER.PDS", copyPath)
+	assert.Equal(t, true, copyBody["replace"])
+	assert.Equal(t, "EXCL", copyBody["enq"])
+	assert.Equal(t, true, copyBody["alias"])
+
+	// Sequential dataset into a PDS member
+	copyBody = nil
+	err = dm.CopyDatasetWithOptions("test.seq", "test.pds", &CopyOptions{TargetMember: "newmem", Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(NEWMEM)", copyPath)
+	assert.Equal(t, map[string]interface{}{
+		"request":      "copy",
+		"from-dataset": map[string]interface{}{"dsn": "TEST.SEQ"},
+		"replace":      true,
+	}, copyBody)
+
+	// Bad options are rejected before anything is sent
+	copyPath = ""
+	assert.Error(t, dm.CopyDatasetWithOptions("TEST.SEQ", "TEST.PDS", &CopyOptions{Enqueue: "OLD"}))
+	assert.Error(t, dm.CopyDatasetWithOptions("TEST.SEQ", "TEST.PDS", &CopyOptions{TargetMember: "BAD MEM"}))
+	assert.Empty(t, copyPath)
+}
+
+func TestCopyMemberToSequential(t *testing.T) {
+	var copyPath string
+	var copyBody map[string]interface{}
+	server := newCopyTestServer(t, &copyPath, &copyBody)
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.CopyMemberToSequential("TEST.PDS", "MEMBER1", "TEST.SEQ")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.SEQ", copyPath)
+	assert.Equal(t, "copy", copyBody["request"])
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.PDS", "member": "MEMBER1"}, copyBody["from-dataset"])
+
+	// Lowercase names are uppercased
+	copyPath, copyBody = "", nil
+	err = dm.CopyMemberToSequential("test.pds", "member1", "test.seq")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/restfiles/ds/TEST.SEQ", copyPath)
+	assert.Equal(t, map[string]interface{}{"dsn": "TEST.PDS", "member": "MEMBER1"}, copyBody["from-dataset"])
+
+	// Organizations are checked before copying
+	copyPath = ""
+	err = dm.CopyMemberToSequential("TEST.SEQ", "MEMBER1", "TEST.SEQ")
+	assert.Error(t, err)
+	err = dm.CopyMemberToSequential("TEST.PDS", "bad member", "TEST.SEQ")
+	assert.Error(t, err)
+	assert.Empty(t, copyPath)
+}
+
 func TestRenameDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -502,6 +1542,312 @@ func TestRenameDataset(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRenameMember(t *testing.T) {
+	var renamed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			// Member listing used for the existence check
+			assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS/member", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("pattern") == "TAKEN" {
+				w.Write([]byte(`{"items":[{"member":"TAKEN"}],"returnedRows":1}`))
+				return
+			}
+			w.Write([]byte(`{"items":[],"returnedRows":0}`))
+			return
+		}
+
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(NEWMEM)", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"request": "rename",
+			"from-dataset": map[string]interface{}{
+				"dsn":    "TEST.PDS",
+				"member": "OLDMEM",
+			},
+		}, body)
+		renamed = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	require.NoError(t, dm.RenameMember("test.pds", "oldmem", "newmem"))
+	assert.True(t, renamed)
+
+	// An existing target is never overwritten
+	renamed = false
+	err = dm.RenameMember("TEST.PDS", "OLDMEM", "TAKEN")
+	assert.ErrorIs(t, err, ErrMemberExists)
+	assert.False(t, renamed)
+
+	assert.Error(t, dm.RenameMember("TEST.PDS", "OLDMEM", "TOOLONGNAME"))
+	assert.Error(t, dm.RenameMember("TEST.PDS", "", "NEWMEM"))
+	assert.False(t, renamed)
+}
+
+func TestCreateVSAM(t *testing.T) {
+	var input []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ams", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		input = body["input"].([]interface{})
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"output":["IDC0002I IDCAMS PROCESSING COMPLETE. MAXIMUM CONDITION CODE WAS 0"]}`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.CreateVSAM(&CreateVSAMRequest{
+		Name:              "test.ksds",
+		KeyLength:         8,
+		KeyOffset:         0,
+		AverageRecordSize: 80,
+		MaxRecordSize:     200,
+		CISize:            4096,
+		Space:             Space{Primary: 1, Secondary: 1, Unit: SpaceUnitCylinders},
+		Volumes:           []string{"vol001"},
+		StorageClass:      "standard",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		"DEFINE CLUSTER -",
+		"(NAME('TEST.KSDS') -",
+		"INDEXED -",
+		"KEYS(8 0) -",
+		"RECORDSIZE(80 200) -",
+		"CONTROLINTERVALSIZE(4096) -",
+		"CYLINDERS(1 1) -",
+		"VOLUMES(VOL001) -",
+		"STORAGECLASS(STANDARD) -",
+		")",
+	}, input)
+
+	// An ESDS has no keys, and unset sizes are left to IDCAMS
+	err = dm.CreateVSAM(&CreateVSAMRequest{
+		Name:         "TEST.ESDS",
+		Organization: VSAMESDS,
+		Space:        Space{Primary: 10, Secondary: 5, Unit: SpaceUnitTracks},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		"DEFINE CLUSTER -",
+		"(NAME('TEST.ESDS') -",
+		"NONINDEXED -",
+		"TRACKS(10 5) -",
+		")",
+	}, input)
+}
+
+func TestInvokeAMS(t *testing.T) {
+	statements := []string{
+		"DELETE TEST.KSDS CLUSTER",
+		"SET MAXCC = 0",
+	}
+	var reply string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ams", r.URL.Path)
+
+		var body struct {
+			Input []string `json:"input"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, statements, body.Input)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(reply))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	reply = `{"output":["IDCAMS  SYSTEM SERVICES",` +
+		`"IDC3012I ENTRY TEST.KSDS NOT FOUND","IDC0551I ** ENTRY TEST.KSDS NOT DELETED",` +
+		`"IDC0001I FUNCTION COMPLETED, HIGHEST CONDITION CODE WAS 8",` +
+		`"IDC0002I IDCAMS PROCESSING COMPLETE. MAXIMUM CONDITION CODE WAS 8"]}`
+	response, err := dm.InvokeAMS(statements)
+	require.NoError(t, err)
+	assert.True(t, response.HasReturnCode)
+	assert.Equal(t, 8, response.ReturnCode)
+	assert.Len(t, response.Output, 5)
+	assert.Equal(t, "IDC3012I ENTRY TEST.KSDS NOT FOUND", response.Output[1])
+
+	// The listing can also arrive as a single string
+	reply = `{"output":"IDCAMS  SYSTEM SERVICES\nIDC0002I IDCAMS PROCESSING COMPLETE. MAXIMUM CONDITION CODE WAS 0\n"}`
+	response, err = dm.InvokeAMS(statements)
+	require.NoError(t, err)
+	assert.Equal(t, 0, response.ReturnCode)
+	assert.Equal(t, []string{"IDCAMS  SYSTEM SERVICES", "IDC0002I IDCAMS PROCESSING COMPLETE. MAXIMUM CONDITION CODE WAS 0"}, response.Output)
+
+	// A listing without a condition code says so rather than passing for 0
+	reply = `{"output":["IDCAMS  SYSTEM SERVICES"]}`
+	response, err = dm.InvokeAMS(statements)
+	require.NoError(t, err)
+	assert.False(t, response.HasReturnCode)
+
+	_, err = dm.InvokeAMS(nil)
+	assert.Error(t, err)
+	_, err = dm.InvokeAMS([]string{strings.Repeat("X", 256)})
+	assert.Error(t, err)
+}
+
+func TestCreateVSAMConditionCode(t *testing.T) {
+	reply := `{"output":["IGD17101I DATA SET TEST.KSDS NOT DEFINED BECAUSE DUPLICATE NAME EXISTS IN CATALOG",` +
+		`"IDC0002I IDCAMS PROCESSING COMPLETE. MAXIMUM CONDITION CODE WAS 12"]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(reply))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.CreateVSAM(&CreateVSAMRequest{
+		Name:      "TEST.KSDS",
+		KeyLength: 8,
+		Space:     Space{Primary: 1, Unit: SpaceUnitCylinders},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "condition code 12")
+	assert.Contains(t, err.Error(), "DUPLICATE NAME")
+
+	// No condition code at all can't be taken as success
+	reply = `{"output":["IDCAMS  SYSTEM SERVICES"]}`
+	err = dm.CreateVSAM(&CreateVSAMRequest{
+		Name:      "TEST.KSDS",
+		KeyLength: 8,
+		Space:     Space{Primary: 1, Unit: SpaceUnitCylinders},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no condition code")
+}
+
+func TestValidateCreateVSAMRequest(t *testing.T) {
+	valid := func() *CreateVSAMRequest {
+		return &CreateVSAMRequest{
+			Name:          "TEST.KSDS",
+			KeyLength:     8,
+			MaxRecordSize: 80,
+			Space:         Space{Primary: 1, Unit: SpaceUnitCylinders},
+		}
+	}
+	require.NoError(t, ValidateCreateVSAMRequest(valid()))
+
+	tests := []struct {
+		name   string
+		change func(r *CreateVSAMRequest)
+	}{
+		{"bad name", func(r *CreateVSAMRequest) { r.Name = "1BAD.NAME" }},
+		{"no key", func(r *CreateVSAMRequest) { r.KeyLength = 0 }},
+		{"key too long", func(r *CreateVSAMRequest) { r.KeyLength = 256; r.MaxRecordSize = 1000 }},
+		{"key past record", func(r *CreateVSAMRequest) { r.KeyOffset = 76 }},
+		{"keys on ESDS", func(r *CreateVSAMRequest) { r.Organization = VSAMESDS }},
+		{"unknown organization", func(r *CreateVSAMRequest) { r.Organization = "SPANNED" }},
+		{"record size on LDS", func(r *CreateVSAMRequest) { r.Organization = VSAMLDS; r.KeyLength = 0 }},
+		{"average over max", func(r *CreateVSAMRequest) { r.AverageRecordSize = 100 }},
+		{"variable RRDS", func(r *CreateVSAMRequest) {
+			r.Organization = VSAMRRDS
+			r.KeyLength = 0
+			r.AverageRecordSize = 40
+		}},
+		{"odd CI size", func(r *CreateVSAMRequest) { r.CISize = 1000 }},
+		{"CI size above 8K off step", func(r *CreateVSAMRequest) { r.CISize = 9216 }},
+		{"record exceeds CI", func(r *CreateVSAMRequest) { r.MaxRecordSize = 600; r.CISize = 512 }},
+		{"no space", func(r *CreateVSAMRequest) { r.Space.Primary = 0 }},
+		{"GB space", func(r *CreateVSAMRequest) { r.Space.Unit = SpaceUnitGB }},
+		{"bad volume", func(r *CreateVSAMRequest) { r.Volumes = []string{"VOLUME1"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := valid()
+			tt.change(request)
+			assert.Error(t, ValidateCreateVSAMRequest(request))
+		})
+	}
+}
+
+func TestMigrateAndRecallDataset(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	require.NoError(t, dm.MigrateDataset("TEST.DATA"))
+	require.NoError(t, dm.RecallDataset("TEST.DATA", true))
+	require.NoError(t, dm.RecallDataset("TEST.DATA", false))
+
+	require.Len(t, bodies, 3)
+	assert.Equal(t, map[string]interface{}{"request": "hmigrate"}, bodies[0])
+	assert.Equal(t, map[string]interface{}{"request": "hrecall", "wait": true}, bodies[1])
+	assert.Equal(t, map[string]interface{}{"request": "hrecall"}, bodies[2])
+}
+
+func TestIsMigrated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := DatasetList{
+			Datasets: []Dataset{
+				{Name: "TEST.MIGR", Migrated: "YES", Volume: "MIGRAT"},
+				{Name: "TEST.OLDMIGR", Volume: "MIGRAT"},
+				{Name: "TEST.ONLINE", Migrated: "NO", Volume: "VOL001"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	migrated, err := dm.IsMigrated("TEST.MIGR")
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	migrated, err = dm.IsMigrated("TEST.OLDMIGR")
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	migrated, err = dm.IsMigrated("TEST.ONLINE")
+	require.NoError(t, err)
+	assert.False(t, migrated)
+
+	_, err = dm.IsMigrated("TEST.MISSING")
+	assert.Error(t, err)
+}
+
 func TestCloseDatasetManager(t *testing.T) {
 	// Create a test session
 	profile := &profile.ZOSMFProfile{
@@ -587,73 +1933,203 @@ func TestCreatePartitionedDataset(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create dataset manager
+	// Create dataset manager
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Test create partitioned dataset
+	err = dm.CreatePartitionedDataset("TEST.PDS")
+	assert.NoError(t, err)
+}
+
+func TestUploadText(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
+		
+		// Verify content type
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		
+		// Read and verify request body
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World!", string(body))
+		
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// Create dataset manager
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Test upload text
+	err = dm.UploadText("TEST.DATA", "Hello, World!")
+	assert.NoError(t, err)
+}
+
+func TestUploadTextToMember(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)  // Changed from POST to PUT for members
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))  // Changed from JSON to plain text
+		
+		// Read request body as plain text
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		
+		// Verify request body is plain text
+		assert.Equal(t, "Hello, World!", string(body))
+		
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// Create dataset manager
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	// Test upload text to member
+	err = dm.UploadTextToMember("TEST.PDS", "MEMBER1", "Hello, World!")
+	assert.NoError(t, err)
+
+	// Lowercase names are uppercased
+	err = dm.UploadTextToMember("test.pds", "member1", "Hello, World!")
+	assert.NoError(t, err)
+}
+
+func TestUploadMembers(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		mu.Lock()
+		uploaded[r.URL.Path] = string(body)
+		mu.Unlock()
+
+		if r.URL.Path == "/api/v1/restfiles/ds/TEST.PDS(MEMBER4)" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"category":6,"rc":4,"reason":8,"message":"I/O error"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
 	profile := createTestProfile(server.URL)
 	session, err := profile.NewSession()
 	require.NoError(t, err)
 	dm := NewDatasetManager(session)
 
-	// Test create partitioned dataset
-	err = dm.CreatePartitionedDataset("TEST.PDS")
-	assert.NoError(t, err)
+	failed, err := dm.UploadMembers("TEST.PDS", map[string]string{
+		"MEMBER1":   "one",
+		"MEMBER2":   "two",
+		"MEMBER3":   "three",
+		"MEMBER4":   "four",
+		"TOOLONGNM": "bad",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "member TOOLONGNM")
+	assert.Contains(t, err.Error(), "member MEMBER4")
+
+	// The bad name never reaches the server; the server failure doesn't stop the others
+	require.Len(t, failed, 2)
+	assert.Contains(t, failed["TOOLONGNM"].Error(), "invalid member name")
+	assert.Error(t, failed["MEMBER4"])
+	assert.Len(t, uploaded, 4)
+	assert.Equal(t, "one", uploaded["/api/v1/restfiles/ds/TEST.PDS(MEMBER1)"])
+	assert.Equal(t, "three", uploaded["/api/v1/restfiles/ds/TEST.PDS(MEMBER3)"])
+
+	// Lowercase names are uppercased, and results keep the names as given
+	uploaded = make(map[string]string)
+	failed, err = dm.UploadMembers("test.pds", map[string]string{
+		"member1": "one",
+		"member4": "four",
+	})
+	require.Error(t, err)
+	require.Len(t, failed, 1)
+	assert.Error(t, failed["member4"])
+	assert.Equal(t, "one", uploaded["/api/v1/restfiles/ds/TEST.PDS(MEMBER1)"])
+
+	// Two spellings of one member would race, so the second is refused
+	uploaded = make(map[string]string)
+	failed, err = dm.UploadMembers("TEST.PDS", map[string]string{
+		"MEMBER1": "one",
+		"member1": "other",
+	})
+	require.Error(t, err)
+	require.Len(t, failed, 1)
+	assert.Contains(t, failed["member1"].Error(), "also given as MEMBER1")
+	assert.Equal(t, "one", uploaded["/api/v1/restfiles/ds/TEST.PDS(MEMBER1)"])
+
+	// Nothing failing means no error at all
+	failed, err = dm.UploadMembers("TEST.PDS", map[string]string{"MEMBER1": "one"})
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+
+	_, err = dm.UploadMembers("", map[string]string{"MEMBER1": "one"})
+	assert.Error(t, err)
 }
 
-func TestUploadText(t *testing.T) {
-	// Create test server
+func TestUploadBinary(t *testing.T) {
+	// Bytes that text handling would mangle
+	data := []byte{0x00, 0x0A, 0x0D, 0x0A, 0xC1, 0xFF, 0x15}
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "PUT", r.Method)
-		assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
-		
-		// Verify content type
-		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
-		
-		// Read and verify request body
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.LOAD(PROG1)", r.URL.Path)
+		assert.Equal(t, "binary", r.Header.Get("X-IBM-Data-Type"))
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+
 		body, err := io.ReadAll(r.Body)
-		require.NoError(t, err)
-		assert.Equal(t, "Hello, World!", string(body))
-		
-		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, err)
+		assert.Equal(t, data, body)
+
+		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer server.Close()
 
-	// Create dataset manager
 	profile := createTestProfile(server.URL)
 	session, err := profile.NewSession()
 	require.NoError(t, err)
 	dm := NewDatasetManager(session)
 
-	// Test upload text
-	err = dm.UploadText("TEST.DATA", "Hello, World!")
+	err = dm.UploadBinary("TEST.LOAD", "PROG1", data)
 	assert.NoError(t, err)
 }
 
-func TestUploadTextToMember(t *testing.T) {
-	// Create test server
+func TestDownloadBinary(t *testing.T) {
+	data := []byte{0x00, 0x0D, 0x0A, 0x25, 0xFF}
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "PUT", r.Method)  // Changed from POST to PUT for members
-		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
-		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))  // Changed from JSON to plain text
-		
-		// Read request body as plain text
-		body, err := io.ReadAll(r.Body)
-		assert.NoError(t, err)
-		
-		// Verify request body is plain text
-		assert.Equal(t, "Hello, World!", string(body))
-		
-		w.WriteHeader(http.StatusCreated)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.BIN", r.URL.Path)
+		assert.Equal(t, "binary", r.Header.Get("X-IBM-Data-Type"))
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(data)
 	}))
 	defer server.Close()
 
-	// Create dataset manager
 	profile := createTestProfile(server.URL)
 	session, err := profile.NewSession()
 	require.NoError(t, err)
 	dm := NewDatasetManager(session)
 
-	// Test upload text to member
-	err = dm.UploadTextToMember("TEST.PDS", "MEMBER1", "Hello, World!")
-	assert.NoError(t, err)
+	content, err := dm.DownloadBinary("TEST.BIN", "")
+	require.NoError(t, err)
+	assert.Equal(t, data, content)
 }
 
 func TestDownloadText(t *testing.T) {
@@ -661,7 +2137,8 @@ func TestDownloadText(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
 		assert.Equal(t, "/api/v1/restfiles/ds/TEST.DATA", r.URL.Path)
-		assert.Equal(t, "UTF-8", r.URL.Query().Get("encoding"))
+		assert.Equal(t, "text", r.Header.Get("X-IBM-Data-Type"))
+		assert.Empty(t, r.URL.RawQuery)
 		
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("Hello, World!"))
@@ -685,7 +2162,8 @@ func TestDownloadTextFromMember(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
 		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(MEMBER1)", r.URL.Path)
-		assert.Equal(t, "UTF-8", r.URL.Query().Get("encoding"))
+		assert.Equal(t, "text", r.Header.Get("X-IBM-Data-Type"))
+		assert.Empty(t, r.URL.RawQuery)
 		
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("Hello, World!"))
@@ -704,7 +2182,221 @@ func TestDownloadTextFromMember(t *testing.T) {
 	assert.Equal(t, "Hello, World!", content)
 }
 
+func TestDownloadLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"lf", "LINE 1\nLINE 2\n", []string{"LINE 1", "LINE 2"}},
+		{"crlf", "LINE 1\r\nLINE 2\r\n", []string{"LINE 1", "LINE 2"}},
+		{"no trailing newline", "LINE 1\r\nLINE 2", []string{"LINE 1", "LINE 2"}},
+		{"blank record kept", "LINE 1\n\nLINE 3\n", []string{"LINE 1", "", "LINE 3"}},
+		{"empty", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/restfiles/ds/TEST.LOG", r.URL.Path)
+				w.Write([]byte(tt.content))
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			lines, err := dm.DownloadLines("TEST.LOG")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lines)
+		})
+	}
+}
+
+func TestForEachLineStops(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("LINE 1\r\nSTOP\r\nLINE 3\r\n"))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	stop := errors.New("stop")
+	var seen []string
+	err = dm.ForEachLine("TEST.LOG", func(line string) error {
+		seen = append(seen, line)
+		if line == "STOP" {
+			return stop
+		}
+		return nil
+	})
+	assert.Same(t, stop, err)
+	assert.Equal(t, []string{"LINE 1", "STOP"}, seen)
+}
+
+func TestDownloadContentCodepage(t *testing.T) {
+	tests := []struct {
+		encoding string
+		dataType DataType
+		want     string
+	}{
+		{"", "", "text"},
+		{CodepageUTF8, "", "text"},
+		{CodepageIBM1047, "", "text;fileEncoding=IBM-1047"},
+		{CodepageISO88591, DataTypeText, "text;fileEncoding=ISO8859-1"},
+		{"", DataTypeBinary, "binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.want, r.Header.Get("X-IBM-Data-Type"))
+				assert.Empty(t, r.URL.Query().Get("encoding"))
+				w.Write([]byte("DATA"))
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			_, err = dm.DownloadContent(&DownloadRequest{
+				DatasetName: "TEST.DATA",
+				Encoding:    tt.encoding,
+				DataType:    tt.dataType,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // Test validation functions
+func TestGetDatasetsByVolume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/restfiles/ds", r.URL.Path)
+		// No dslevel default: the volume alone selects the datasets
+		assert.Equal(t, "volser=VOL001", r.URL.RawQuery)
+		assert.Equal(t, "25", r.Header.Get("X-IBM-Max-Items"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[{"dsname":"SYS1.PARMLIB","vol":"VOL001"},{"dsname":"OTHER.DATA","vol":"VOL001"}],"returnedRows":2}`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	list, err := dm.GetDatasetsByVolume(" vol001", 25)
+	require.NoError(t, err)
+	assert.Len(t, list.Datasets, 2)
+
+	_, err = dm.GetDatasetsByVolume("", 25)
+	assert.Error(t, err)
+	_, err = dm.GetDatasetsByVolume("VOLUME01", 25)
+	assert.Error(t, err)
+}
+
+func TestListDatasetsDefaultLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *DatasetFilter
+		want   string
+	}{
+		{"nil filter", nil, "dslevel=testuser.%2A"},
+		{"empty filter", &DatasetFilter{}, "dslevel=testuser.%2A"},
+		{"all datasets", &DatasetFilter{AllDatasets: true}, "dslevel=%2A%2A"},
+		{"name wins", &DatasetFilter{Name: "SYS1.*", AllDatasets: true}, "dslevel=SYS1.%2A"},
+		{"volume only", &DatasetFilter{Volume: "VOL001", AllDatasets: true}, "volser=VOL001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.want, r.URL.RawQuery)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"items":[],"returnedRows":0}`))
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			_, err = dm.ListDatasets(tt.filter)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListDatasetsMaxItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    *DatasetFilter
+		want      string
+		moreRows  bool
+		truncated bool
+	}{
+		{"default cap", nil, "1000", true, true},
+		{"default cap on filter", &DatasetFilter{Name: "SYS1.**"}, "1000", false, false},
+		{"explicit limit", &DatasetFilter{Name: "SYS1.**", Limit: 50}, "50", true, true},
+		{"limit beats unlimited", &DatasetFilter{Name: "SYS1.**", Limit: 50, Unlimited: true}, "50", false, false},
+		{"unlimited", &DatasetFilter{Name: "SYS1.**", Unlimited: true}, "0", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.want, r.Header.Get("X-IBM-Max-Items"))
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"items":[{"dsname":"SYS1.PARMLIB"}],"returnedRows":1,"moreRows":%t}`, tt.moreRows)
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			dm := NewDatasetManager(session)
+
+			list, err := dm.ListDatasets(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.truncated, list.Truncated)
+		})
+	}
+}
+
+func TestListDatasetsNotReferencedSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TESTUSER.**", r.URL.Query().Get("dslevel"))
+		assert.Equal(t, "base", r.Header.Get("X-IBM-Attributes"))
+
+		// Captured z/OSMF listing with mixed reference dates
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[
+			{"dsname":"TESTUSER.OLD","dsorg":"PS","rdate":"2022/01/15"},
+			{"dsname":"TESTUSER.RECENT","dsorg":"PS","rdate":"2024/06/01"},
+			{"dsname":"TESTUSER.EDGE","dsorg":"PO","rdate":"2024/01/01"},
+			{"dsname":"TESTUSER.NEVER","dsorg":"PS","rdate":"***None***"},
+			{"dsname":"TESTUSER.MIGRATED","migr":"YES","vol":"MIGRAT"}
+		],"returnedRows":5,"JSONversion":1}`))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stale, err := dm.ListDatasetsNotReferencedSince("TESTUSER.**", cutoff)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "TESTUSER.OLD", stale[0].Name)
+	assert.Equal(t, time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC), stale[0].Referenced)
+}
+
 func TestValidateDatasetName(t *testing.T) {
 	// Test valid names
 	validNames := []string{
@@ -712,9 +2404,11 @@ func TestValidateDatasetName(t *testing.T) {
 		"USER.PROGRAM",
 		"SYSTEM.LIBRARY",
 		"MY@DATA",
-		"TEST#FILE",
+		"TEST#FL",
 		"DATA$SET",
 		"A.B.C",
+		"A.B8CHARSX",
+		"USER.V1-2.DATA",
 	}
 
 	for _, name := range validNames {
@@ -733,6 +2427,10 @@ func TestValidateDatasetName(t *testing.T) {
 		"DATA..SET",           // Consecutive periods
 		"DATA--SET",           // Consecutive hyphens
 		"DATA SET",            // Contains space
+		"ABC.123",             // Qualifier starts with number
+		"A.9BAD",              // Qualifier starts with number
+		"A.NINECHARS",         // 9-character qualifier
+		"USER.-DATA",          // Qualifier starts with hyphen
 	}
 
 	for _, name := range invalidNames {
@@ -793,6 +2491,18 @@ func TestValidateCreateDatasetRequest(t *testing.T) {
 	err := ValidateCreateDatasetRequest(validRequest)
 	assert.NoError(t, err)
 
+	// Blocked and carriage-control record formats are valid too
+	for _, recfm := range []RecordFormat{"FB", "VB", "FBA", "VBA", "FBM", "VBS", "U"} {
+		request := *validRequest
+		request.RecordFormat = recfm
+		assert.NoError(t, ValidateCreateDatasetRequest(&request), recfm)
+	}
+	for _, recfm := range []RecordFormat{"X", "FF", "UB", "fb"} {
+		request := *validRequest
+		request.RecordFormat = recfm
+		assert.Error(t, ValidateCreateDatasetRequest(&request), recfm)
+	}
+
 	// Test invalid requests
 	invalidRequests := []*CreateDatasetRequest{
 		nil, // Nil request
@@ -871,6 +2581,85 @@ func TestValidateDownloadRequest(t *testing.T) {
 	}
 }
 
+// countingReader records how many bytes were read from it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestUploadExpectContinue(t *testing.T) {
+	var expect string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect = r.Header.Get("Expect")
+		// Reject without reading the body, so no 100 Continue goes out
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"authentication required"}`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	body := &countingReader{r: strings.NewReader(strings.Repeat("X", 1<<20))}
+	err = dm.UploadContentFrom(&UploadRequest{DatasetName: "TEST.LARGE", ExpectContinue: true}, body)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+	assert.Equal(t, "100-continue", expect)
+	assert.Zero(t, body.n, "body should not be sent after an early rejection")
+}
+
+func TestValidateDataTypeEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType DataType
+		encoding string
+		wantErr  bool
+	}{
+		{"text with encoding", DataTypeText, "IBM-1047", false},
+		{"default with encoding", "", "IBM-037", false},
+		{"binary without encoding", DataTypeBinary, "", false},
+		{"binary with encoding", DataTypeBinary, "IBM-1047", true},
+		{"record with encoding", DataTypeRecord, "IBM-1047", true},
+		{"unknown data type", DataType("bogus"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploadErr := ValidateUploadRequest(&UploadRequest{
+				DatasetName: "TEST.DATA",
+				Content:     "data",
+				DataType:    tt.dataType,
+				Encoding:    tt.encoding,
+			})
+			downloadErr := ValidateDownloadRequest(&DownloadRequest{
+				DatasetName: "TEST.DATA",
+				DataType:    tt.dataType,
+				Encoding:    tt.encoding,
+			})
+			if tt.wantErr {
+				assert.Error(t, uploadErr)
+				assert.Error(t, downloadErr)
+			} else {
+				assert.NoError(t, uploadErr)
+				assert.NoError(t, downloadErr)
+			}
+		})
+	}
+
+	// The manager refuses the conflict before sending anything
+	dm := NewDatasetManager(&profile.Session{})
+	_, err := dm.DownloadContent(&DownloadRequest{DatasetName: "TEST.DATA", DataType: DataTypeBinary, Encoding: "IBM-1047"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only apply to text")
+}
+
 // Test space creation functions
 func TestCreateDefaultSpace(t *testing.T) {
 	space := CreateDefaultSpace(SpaceUnitTracks)
@@ -953,8 +2742,9 @@ func TestCreateDatasetError(t *testing.T) {
 
 	// Test create dataset error
 	request := &CreateDatasetRequest{
-		Name: "TEST.DATA",
-		Type: DatasetTypeSequential,
+		Name:  "TEST.DATA",
+		Type:  DatasetTypeSequential,
+		Space: CreateDefaultSpace(SpaceUnitTracks),
 	}
 	
 	err = dm.CreateDataset(request)
@@ -1130,3 +2920,354 @@ func TestRenameDatasetError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "API request failed with status 400")
 }
+
+func TestAppendContent(t *testing.T) {
+	content := "LINE ONE\nLINE TWO"
+	etag := "v1"
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/restfiles/ds/TEST.PDS(LOG)", r.URL.Path)
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "true", r.Header.Get("X-IBM-Return-Etag"))
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(content))
+		case "PUT":
+			puts++
+			// Simulate another writer winning the first race
+			if puts == 1 {
+				content += "\nOTHER WRITER"
+				etag = "v2"
+			}
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			content = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.AppendContent("TEST.PDS", "LOG", "LINE THREE")
+	require.NoError(t, err)
+	assert.Equal(t, 2, puts)
+	assert.Equal(t, "LINE ONE\nLINE TWO\nOTHER WRITER\nLINE THREE", content)
+}
+
+func TestAPIErrorFromManager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"category":6,"rc":8,"reason":12,"message":"Data set not cataloged"}`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	_, err = dm.DownloadText("TEST.MISSING")
+	require.Error(t, err)
+
+	var apiErr *profile.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 8, apiErr.ReturnCode)
+	assert.Equal(t, 12, apiErr.Reason)
+	assert.Equal(t, "Data set not cataloged", apiErr.Message)
+	assert.True(t, profile.IsNotFound(err))
+}
+
+func TestCompressDataset(t *testing.T) {
+	var jcl string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/restfiles/ds":
+			json.NewEncoder(w).Encode(DatasetList{Datasets: []Dataset{{Name: "TEST.PDS", Type: "PO"}}, ReturnedRows: 1})
+		case r.Method == "PUT" && r.URL.Path == "/api/v1/restjobs/jobs":
+			body, _ := io.ReadAll(r.Body)
+			jcl = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"jobid":"JOB00042","jobname":"COMPRESS","status":"INPUT"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/restjobs/jobs/COMPRESS/JOB00042":
+			w.Write([]byte(`{"jobid":"JOB00042","jobname":"COMPRESS","status":"OUTPUT","retcode":"CC 0000"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	result, err := dm.CompressDatasetWithOptions("TEST.PDS", &CompressOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, "JOB00042", result.JobID)
+	assert.True(t, result.Succeeded)
+	assert.Contains(t, jcl, "EXEC PGM=IEBCOPY")
+	assert.Contains(t, jcl, "DISP=OLD,DSN=TEST.PDS")
+	assert.Contains(t, jcl, "COPY OUTDD=PDS,INDD=PDS")
+}
+
+func TestIsPDSFull(t *testing.T) {
+	assert.True(t, IsPDSFull(profile.NewAPIError(500, []byte(`{"message":"IEC032I E37-04 out of space"}`))))
+	assert.True(t, IsPDSFull(errors.New("directory full")))
+	assert.True(t, IsPDSFull(errors.New("ABEND=SB37-04 REASON=00000000")))
+	assert.True(t, IsPDSFull(errors.New("IEC031I D37-04,IFG0554T,USER1,STEP1")))
+	assert.False(t, IsPDSFull(errors.New("member not found")))
+	// Names that merely contain x37 aren't space failures
+	assert.False(t, IsPDSFull(errors.New("member ABE37X not found in USER.B37.DATA")))
+	assert.False(t, IsPDSFull(errors.New("dataset D37 is in use")))
+	assert.False(t, IsPDSFull(nil))
+}
+
+func TestListDatasetsAll(t *testing.T) {
+	pages := map[string][]string{
+		"":       {"USER.A", "USER.B"},
+		"USER.B": {"USER.B", "USER.C", "USER.D"},
+		"USER.D": {"USER.D", "USER.E"},
+	}
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		assert.Equal(t, "3", r.Header.Get("X-IBM-Max-Items"))
+
+		list := DatasetList{MoreRows: start != "USER.D"}
+		for _, name := range pages[start] {
+			list.Datasets = append(list.Datasets, Dataset{Name: name, Type: "PS"})
+		}
+		list.ReturnedRows = len(list.Datasets)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	all, err := dm.ListDatasetsAll(&DatasetFilter{Name: "USER.*", Limit: 3})
+	require.NoError(t, err)
+	var names []string
+	for _, ds := range all.Datasets {
+		names = append(names, ds.Name)
+	}
+	assert.Equal(t, []string{"USER.A", "USER.B", "USER.C", "USER.D", "USER.E"}, names)
+	assert.Equal(t, []string{"", "USER.B", "USER.D"}, starts)
+	assert.False(t, all.Truncated)
+
+	// The cap stops paging and marks the result truncated
+	starts = nil
+	all, err = dm.ListDatasetsAll(&DatasetFilter{Name: "USER.*", Limit: 3, MaxResults: 3})
+	require.NoError(t, err)
+	assert.Len(t, all.Datasets, 3)
+	assert.True(t, all.Truncated)
+	assert.Len(t, starts, 2)
+
+	// The page callback can stop early
+	pageCount := 0
+	err = dm.ListDatasetsPages(&DatasetFilter{Name: "USER.*", Limit: 3}, func(page *DatasetList) bool {
+		pageCount++
+		return false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pageCount)
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{"sys1.*.load", "SYS1.*.LOAD", false},
+		{"*.LISTING", "*.LISTING", false},
+		{"'USER.**'", "USER.**", false},
+		{"  user.test%%.data ", "USER.TEST%%.DATA", false},
+		{"USER.**.JCL", "USER.**.JCL", false},
+		{"USER.DATA", "USER.DATA", false},
+		{"", "", true},
+		{"*", "", true},
+		{"**.*", "", true},
+		{"USER..DATA", "", true},
+		{"USER.DATA.", "", true},
+		{"USER.A**", "", true},
+		{"USER.**.**", "", true},
+		{"USER.1DATA", "", true},
+		{"USER.TOOLONGQUAL*", "", true},
+		{"USER.BAD!", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			filter, err := ParseFilter(tt.pattern)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Name)
+		})
+	}
+}
+
+func TestDownloadUploadWithETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", "\"ABC123\"")
+			w.Write([]byte("HELLO"))
+		case "PUT":
+			if r.Header.Get("If-Match") != "\"ABC123\"" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`{"category":6,"rc":4,"reason":8,"message":"ETag mismatch"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	content, etag, err := dm.DownloadWithETag(&DownloadRequest{DatasetName: "TEST.PDS", MemberName: "MEM"})
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", content)
+	assert.Equal(t, "\"ABC123\"", etag)
+
+	request := &UploadRequest{DatasetName: "TEST.PDS", MemberName: "MEM", Content: "BYE", Replace: true}
+	require.NoError(t, dm.UploadWithETag(request, etag))
+	assert.Empty(t, request.IfMatch, "the caller's request is left untouched")
+
+	err = dm.UploadWithETag(request, "\"STALE\"")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrETagMismatch)
+	var apiErr *profile.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+}
+
+func TestCreateDatasetValidatesLocally(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	err = dm.CreateDataset(&CreateDatasetRequest{Name: "TEST.DATA", Type: "XX", Space: CreateDefaultSpace(SpaceUnitTracks)})
+	assert.ErrorContains(t, err, "invalid dataset type")
+
+	err = dm.CreateDataset(&CreateDatasetRequest{Name: "TEST.DATA", Type: DatasetTypeSequential, Space: Space{Unit: SpaceUnitTracks}})
+	assert.ErrorContains(t, err, "primary space allocation must be greater than 0")
+
+	err = dm.CreateDatasetWithOptions("TEST.DATA", DatasetTypeSequential, Space{Unit: SpaceUnitTracks}, RecordFormatFixed, 80, 800)
+	assert.Error(t, err)
+	assert.Zero(t, requests, "invalid requests must not reach the server")
+
+	// SkipValidation sends it anyway
+	err = dm.CreateDataset(&CreateDatasetRequest{Name: "TEST.DATA", Type: DatasetTypeSequential, SkipValidation: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestDryRun(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	session.DryRun = true
+	dm := NewDatasetManager(session)
+
+	var planned *profile.PlannedRequest
+
+	// Create
+	err = dm.CreateSequentialDataset("TEST.DATA")
+	require.True(t, errors.As(err, &planned))
+	assert.Equal(t, "POST", planned.Method)
+	assert.Equal(t, session.GetBaseURL()+"/restfiles/ds/TEST.DATA", planned.URL)
+	assert.Equal(t, "REDACTED", planned.Headers["Authorization"])
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(planned.Body, &body))
+	assert.Equal(t, "PS", body["dsorg"])
+	assert.Same(t, planned, session.LastPlannedRequest())
+
+	// Upload
+	err = dm.UploadText("TEST.DATA", "HELLO")
+	require.True(t, errors.As(err, &planned))
+	assert.Equal(t, "PUT", planned.Method)
+	assert.Equal(t, "HELLO", string(planned.Body))
+	assert.Equal(t, "text/plain", planned.Headers["Content-Type"])
+
+	// Delete
+	err = dm.DeleteDataset("TEST.DATA")
+	assert.True(t, profile.IsDryRun(err))
+	assert.Equal(t, "DELETE", session.LastPlannedRequest().Method)
+
+	assert.Zero(t, requests, "dry run must not reach the server")
+}
+
+func TestNormalizeDatasetName(t *testing.T) {
+	name, err := NormalizeDatasetName("myuser.test")
+	require.NoError(t, err)
+	assert.Equal(t, "MYUSER.TEST", name)
+
+	name, err = NormalizeDatasetName(" sys1.proclib ")
+	require.NoError(t, err)
+	assert.Equal(t, "SYS1.PROCLIB", name)
+
+	_, err = NormalizeDatasetName("myuser.te st")
+	assert.Error(t, err)
+	_, err = NormalizeDatasetName("myuser.9bad")
+	assert.Error(t, err)
+
+	member, err := NormalizeMemberName("iefbr14")
+	require.NoError(t, err)
+	assert.Equal(t, "IEFBR14", member)
+}
+
+func TestLowercaseNamesAreUppercased(t *testing.T) {
+	var paths []string
+	var createBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&createBody)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	dm := NewDatasetManager(session)
+
+	require.NoError(t, dm.CreateSequentialDataset("myuser.test"))
+	assert.Equal(t, "MYUSER.TEST", createBody["dsname"])
+	require.NoError(t, dm.UploadContent(&UploadRequest{DatasetName: "myuser.pds", MemberName: "mem1", Content: "X", Replace: true}))
+
+	assert.Equal(t, []string{
+		"POST /api/v1/restfiles/ds/MYUSER.TEST",
+		"PUT /api/v1/restfiles/ds/MYUSER.PDS(MEM1)",
+	}, paths)
+}
@@ -2,12 +2,17 @@ package datasets
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
 )
@@ -19,6 +24,9 @@ const (
 	
 	// Dataset by name
 	DatasetByNameEndpoint = "/restfiles/ds/%s"
+
+	// Dataset by name on a specific volume
+	DatasetOnVolumeEndpoint = "/restfiles/ds/-(%s)/%s"
 	
 	// Member endpoints
 	MembersEndpoint  = "/member"
@@ -30,6 +38,9 @@ const (
 	// Content endpoints
 	DatasetContentEndpoint = "/content"
 	MemberContentEndpoint  = "/content/%s"
+
+	// Access Method Services (IDCAMS) commands
+	AMSEndpoint = "/restfiles/ams"
 )
 
 // NewDatasetManager creates a dataset manager with the given session
@@ -39,6 +50,15 @@ func NewDatasetManager(session *profile.Session) *ZOSMFDatasetManager {
 	}
 }
 
+// getSession returns the manager's session, or profile.ErrNoSession when
+// the manager was created with a nil one
+func (dm *ZOSMFDatasetManager) getSession() (*profile.Session, error) {
+	if dm.session == nil {
+		return nil, profile.ErrNoSession
+	}
+	return dm.session, nil
+}
+
 // NewDatasetManagerFromProfile creates a dataset manager from a profile
 func NewDatasetManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFDatasetManager, error) {
 	session, err := profile.NewSession()
@@ -50,7 +70,24 @@ func NewDatasetManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFDatasetM
 
 // ListDatasets gets datasets matching the filter
 func (dm *ZOSMFDatasetManager) ListDatasets(filter *DatasetFilter) (*DatasetList, error) {
-	session := dm.session.(*profile.Session)
+	session, err := dm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := AttributesBase
+	if filter != nil && filter.Attributes != "" {
+		attributes = filter.Attributes
+	}
+	switch attributes {
+	case AttributesBase, AttributesVolume, AttributesName:
+	default:
+		return nil, fmt.Errorf("invalid list attributes %q", attributes)
+	}
+	// Type filtering needs dsorg, which only base listings carry
+	if filter != nil && filter.Type != "" && attributes != AttributesBase {
+		return nil, fmt.Errorf("filtering by type needs %s attributes, not %s", AttributesBase, attributes)
+	}
 	
 	// Build query parameters
 	params := url.Values{}
@@ -69,68 +106,64 @@ func (dm *ZOSMFDatasetManager) ListDatasets(filter *DatasetFilter) (*DatasetList
 			params.Set("volser", filter.Volume)
 			hasRequiredParam = true
 		}
-		if filter.Owner != "" {
+		if filter.Start != "" {
+			// Starting dataset name for pagination
+			params.Set("start", filter.Start)
+		} else if filter.Owner != "" {
 			// Starting dataset name for pagination
 			params.Set("start", filter.Owner)
 		}
 		// Limit is handled via header, not query param
+		// Type has no query param; it is applied to the results below
 	}
 	
-	// Default to user's datasets if no filter specified
+	// Default to user's datasets if no filter specified, unless the caller
+	// asked for everything
 	if !hasRequiredParam {
-		// Use user ID to avoid listing everything
-		params.Set("dslevel", session.User+".*")
+		if filter != nil && filter.AllDatasets {
+			params.Set("dslevel", "**")
+		} else {
+			// Use user ID to avoid listing everything
+			params.Set("dslevel", session.User+".*")
+		}
 	}
 
-	// Build URL
-	apiURL := session.GetBaseURL() + DatasetsEndpoint
-	if len(params) > 0 {
-		apiURL += "?" + params.Encode()
+	// Choose how much detail comes back per dataset
+	headers := map[string]string{
+		"X-IBM-Attributes": string(attributes),
 	}
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	// Set result limit; "0" (return everything) only on explicit request,
+	// since a broad pattern can otherwise pull in a whole catalog
+	switch {
+	case filter != nil && filter.Limit > 0:
+		headers["X-IBM-Max-Items"] = strconv.Itoa(filter.Limit)
+	case filter != nil && filter.Unlimited:
+		headers["X-IBM-Max-Items"] = "0"
+	default:
+		headers["X-IBM-Max-Items"] = strconv.Itoa(DefaultListLimit)
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-	
-	// Set result limit
-	if filter != nil && filter.Limit > 0 {
-		req.Header.Set("X-IBM-Max-Items", strconv.Itoa(filter.Limit))
-	} else {
-		req.Header.Set("X-IBM-Max-Items", "0") // 0 = no limit
+	var raw json.RawMessage
+	path := DatasetsEndpoint + "?" + params.Encode()
+	if err := session.DoJSONWithHeaders(context.Background(), "GET", path, nil, &raw, headers); err != nil {
+		return nil, err
 	}
-	
-	// Get basic attributes only
-	req.Header.Set("X-IBM-Attributes", "base")
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+	var datasetList DatasetList
+	if err := profile.DecodeJSON(raw, &datasetList, dm.StrictJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	populateReferenced(&datasetList)
+	datasetList.Truncated = datasetList.MoreRows
+	if n := len(datasetList.Datasets); n > 0 {
+		datasetList.lastName = datasetList.Datasets[n-1].Name
 	}
 
-	// Parse response
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-	
-	var datasetList DatasetList
-	if err := json.Unmarshal(bodyBytes, &datasetList); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	// z/OSMF can't filter by dsorg, so do it client-side
+	if filter != nil && filter.Type != "" {
+		filterDatasetsByType(&datasetList, filter.Type)
 	}
 
 	return &datasetList, nil
@@ -138,91 +171,54 @@ func (dm *ZOSMFDatasetManager) ListDatasets(filter *DatasetFilter) (*DatasetList
 
 // GetDataset gets info for a specific dataset
 func (dm *ZOSMFDatasetManager) GetDataset(name string) (*Dataset, error) {
-	// Use list API to get metadata
-	dl, err := dm.ListDatasets(&DatasetFilter{Name: name})
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	// The list API is where z/OSMF serves attributes; scope it to the name
+	dl, err := dm.ListDatasets(&DatasetFilter{Name: name, Attributes: AttributesBase})
 	if err != nil {
 		return nil, err
 	}
 	
-	// Find the dataset in results
+	// dslevel can match more than the exact name, so pick it out
 	for _, ds := range dl.Datasets {
 		if ds.Name == name {
 			return &ds, nil
 		}
 	}
 	
-	return nil, fmt.Errorf("dataset not found: %s", name)
+	return nil, fmt.Errorf("%w: %s", ErrDatasetNotFound, name)
 }
 
-// GetDatasetInfo gets detailed dataset info, trying direct API first
+// GetDatasetInfo gets the full (base) attributes of a single dataset. It
+// fails with ErrDatasetNotFound when no dataset has exactly that name.
 func (dm *ZOSMFDatasetManager) GetDatasetInfo(name string) (*Dataset, error) {
-	// Try direct API first
-	dataset, err := dm.getDatasetInfoDirect(name)
-	if err == nil {
-		return dataset, nil
-	}
-	
-	// Fall back to list API
 	return dm.GetDataset(name)
 }
 
-// getDatasetInfoDirect tries to get dataset info via direct API
-func (dm *ZOSMFDatasetManager) getDatasetInfoDirect(name string) (*Dataset, error) {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL for direct dataset access
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(name))
-	
-	// Request metadata, not content
-	params := url.Values{}
-	params.Set("metadata", "true")
-	apiURL += "?" + params.Encode()
-
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-	req.Header.Set("Accept", "application/json")
-
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
+// CreateDataset creates a new dataset using the correct z/OSMF REST API format
+// Based on IBM documentation: POST /zosmf/restfiles/ds/<data-set-name>
+func (dm *ZOSMFDatasetManager) CreateDataset(request *CreateDatasetRequest) error {
+	_, err := dm.CreateDatasetWithResponse(request)
+	return err
+}
 
-	// Check response status
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("dataset not found: %s", name)
-	}
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// CreateDatasetWithResponse creates a dataset like CreateDataset and also
+// returns z/OSMF's reply, whose headers carry the created resource's Location
+func (dm *ZOSMFDatasetManager) CreateDatasetWithResponse(request *CreateDatasetRequest) (*profile.Response, error) {
+	// Dataset names are uppercase on z/OS; accept any case from callers
+	if request != nil {
+		normalized := *request
+		normalized.Name = strings.ToUpper(strings.TrimSpace(request.Name))
+		request = &normalized
 	}
 
-	// Try to parse response body as JSON
-	var dataset Dataset
-	if err := json.NewDecoder(resp.Body).Decode(&dataset); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	// Reject invalid requests before they reach the server
+	if request == nil || !request.SkipValidation {
+		if err := ValidateCreateDatasetRequest(request); err != nil {
+			return nil, err
+		}
 	}
 
-	return &dataset, nil
-}
-
-// CreateDataset creates a new dataset using the correct z/OSMF REST API format
-// Based on IBM documentation: POST /zosmf/restfiles/ds/<data-set-name>
-func (dm *ZOSMFDatasetManager) CreateDataset(request *CreateDatasetRequest) error {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL using the correct format from IBM documentation
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(request.Name))
-
 	// Prepare request body
 	requestBody := map[string]interface{}{
 		"dsname": request.Name,
@@ -254,109 +250,223 @@ func (dm *ZOSMFDatasetManager) CreateDataset(request *CreateDatasetRequest) erro
 		requestBody["dirblk"] = request.Directory
 	}
 
-	// Serialize request body
-	jsonBody, err := json.Marshal(requestBody)
+	return dm.allocateDataset(request.Name, requestBody)
+}
+
+// CreateDatasetLike allocates a dataset with the attributes of an existing
+// model dataset (the z/OSMF "like" allocation). Pass space to override the
+// model's space allocation, or nil to copy it too.
+func (dm *ZOSMFDatasetManager) CreateDatasetLike(newName, modelName string, space *Space) error {
+	newName, err := NormalizeDatasetName(newName)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return fmt.Errorf("invalid dataset name: %w", err)
 	}
-
-	// Create request
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonBody))
+	modelName, err = NormalizeDatasetName(modelName)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("invalid model dataset name: %w", err)
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	requestBody := map[string]interface{}{
+		"like": modelName,
+	}
+	if space != nil {
+		requestBody["alcunit"] = string(space.Unit)
+		requestBody["primary"] = space.Primary
+		requestBody["secondary"] = space.Secondary
+		if space.Directory > 0 {
+			requestBody["dirblk"] = space.Directory
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	_, err = dm.allocateDataset(newName, requestBody)
+	return err
+}
+
+// allocateDataset sends a z/OSMF allocation request for name and returns
+// the successful reply
+func (dm *ZOSMFDatasetManager) allocateDataset(name string, requestBody map[string]interface{}) (*profile.Response, error) {
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, err
+	}
+	
+	// Serialize request body
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// The reply's headers carry the Location, so keep the raw response
+	resp, err := session.DoRaw(context.Background(), "POST", datasetPath(name, ""), bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return nil
+	body, _ := session.ReadBody(resp.Body)
+	return &profile.Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
 }
 
 // DeleteDataset deletes a dataset
 func (dm *ZOSMFDatasetManager) DeleteDataset(name string) error {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL using template
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(name))
+	return dm.DeleteDatasetWithOptions(name, nil)
+}
+
+// DeleteDatasetOnVolume deletes an uncataloged (or duplicate) dataset from
+// the given volume, using the -(volser) URL form
+func (dm *ZOSMFDatasetManager) DeleteDatasetOnVolume(name, volume string) error {
+	return dm.DeleteDatasetWithOptions(name, &DeleteOptions{Volume: volume})
+}
+
+// DeleteDatasetWithOptions deletes a dataset, optionally from a specific
+// volume or recalling it from HSM first. A dataset another job or user has
+// allocated fails with ErrDatasetInUse.
+func (dm *ZOSMFDatasetManager) DeleteDatasetWithOptions(name string, options *DeleteOptions) error {
+	if options == nil {
+		options = &DeleteOptions{}
+	}
+
+	if options.RecallMigrated && options.Volume == "" {
+		migrated, err := dm.IsMigrated(name)
+		if err != nil {
+			return fmt.Errorf("failed to check migration status: %w", err)
+		}
+		if migrated {
+			if err := dm.RecallDataset(name, true); err != nil {
+				return fmt.Errorf("failed to recall dataset: %w", err)
+			}
+		}
+	}
 
-	// Create request
-	req, err := http.NewRequest("DELETE", apiURL, nil)
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
+	}
+	
+	path := datasetPath(name, "")
+	if options.Volume != "" {
+		path = datasetOnVolumePath(name, options.Volume)
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	err = session.DoJSON(context.Background(), "DELETE", path, nil, nil)
+	var apiErr *profile.APIError
+	if errors.As(err, &apiErr) && isInUseError(apiErr) {
+		return fmt.Errorf("%w: %s: %w", ErrDatasetInUse, strings.ToUpper(name), apiErr)
 	}
+	return err
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+// isInUseError reports whether z/OSMF rejected a request because the
+// dataset is allocated (enqueued) elsewhere
+func isInUseError(apiErr *profile.APIError) bool {
+	text := strings.ToLower(apiErr.Message + " " + strings.Join(apiErr.Details, " "))
+	return strings.Contains(text, "in use") || strings.Contains(text, "enqueue") ||
+		strings.Contains(text, "allocated to another")
+}
+
+// DeleteDatasetIfExists deletes a dataset, treating a missing dataset as success.
+// It returns deleted=false with no error when z/OSMF reports the dataset is gone,
+// which avoids racing an Exists check against the delete.
+func (dm *ZOSMFDatasetManager) DeleteDatasetIfExists(name string) (bool, error) {
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return false, err
 	}
-	defer resp.Body.Close()
+	
+	err = session.DoJSON(context.Background(), "DELETE", datasetPath(name, ""), nil, nil)
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// Already gone counts as done
+	var apiErr *profile.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 // UploadContent uploads content to a dataset
 func (dm *ZOSMFDatasetManager) UploadContent(request *UploadRequest) error {
-	session := dm.session.(*profile.Session)
+	// Raw bytes are sent untouched; otherwise send the string content
+	content := request.Data
+	if content == nil {
+		content = []byte(request.Content)
+	}
+	return dm.UploadContentFrom(request, bytes.NewReader(content))
+}
+
+// UploadContentFrom uploads content read from r, so large files don't need
+// to be buffered. Content and Data on the request are ignored. Content-Length
+// is sent when the size is known (files and in-memory readers); anything else
+// is sent chunked.
+func (dm *ZOSMFDatasetManager) UploadContentFrom(request *UploadRequest, r io.Reader) error {
+	session, err := dm.getSession()
+	if err != nil {
+		return err
+	}
+
+	if err := validateDataType(request.DataType, request.Encoding); err != nil {
+		return err
+	}
+	
+	// Without Replace, only create members that aren't there yet
+	if request.MemberName != "" && !request.Replace {
+		exists, err := dm.memberExists(request.DatasetName, request.MemberName)
+		if err != nil {
+			return fmt.Errorf("failed to check member existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: %s(%s)", ErrMemberExists, request.DatasetName, request.MemberName)
+		}
+	}
 	
 	// Build URL using correct z/OSMF format
 	var apiURL string
 	if request.MemberName != "" {
 		// For members, use dataset(member) format
-		apiURL = session.GetBaseURL() + fmt.Sprintf("/restfiles/ds/%s(%s)", url.PathEscape(request.DatasetName), url.PathEscape(request.MemberName))
+		apiURL = datasetURL(session, request.DatasetName, request.MemberName)
 	} else {
 		// For datasets, use the dataset endpoint directly (no /content suffix)
-		apiURL = session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(request.DatasetName))
+		apiURL = datasetURL(session, request.DatasetName, "")
 	}
 
-	var req *http.Request
-	var err error
-
-	if request.MemberName != "" {
-		// For members, use PUT with plain text content
-		req, err = http.NewRequest("PUT", apiURL, bytes.NewBufferString(request.Content))
-	} else {
-		// For datasets, use PUT with plain text content (per z/OSMF API specification)
-		req, err = http.NewRequest("PUT", apiURL, bytes.NewBufferString(request.Content))
-	}
+	// Built by hand rather than through DoRaw, which can't set the length
+	// of a streamed body
+	req, err := http.NewRequest("PUT", apiURL, r)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// NewRequest sizes in-memory readers itself; files need a stat
+	if f, ok := r.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to stat upload file: %w", err)
+		}
+		if info.Mode().IsRegular() {
+			offset, err := f.Seek(0, io.SeekCurrent)
+			if err == nil {
+				req.ContentLength = info.Size() - offset
+			}
+		}
+	}
+
 	// Add headers
 	for key, value := range session.GetHeaders() {
 		req.Header.Set(key, value)
 	}
 	
-	// For both datasets and members, use plain text content type (per z/OSMF API specification)
-	req.Header.Set("Content-Type", "text/plain")
+	// Text uses plain text content type (per z/OSMF API specification)
+	req.Header.Set("Content-Type", contentTypeFor(request.DataType))
+	req.Header.Set("X-IBM-Data-Type", dataTypeHeader(request.DataType, request.Encoding))
+	if request.ExpectContinue {
+		req.Header.Set("Expect", "100-continue")
+	}
+	if request.IfMatch != "" {
+		req.Header.Set("If-Match", request.IfMatch)
+	}
 
 	// Make request
 	resp, err := session.GetHTTPClient().Do(req)
@@ -365,10 +475,15 @@ func (dm *ZOSMFDatasetManager) UploadContent(request *UploadRequest) error {
 	}
 	defer resp.Body.Close()
 
-	// Check response status
+	// Check response status. With ExpectContinue an early rejection lands
+	// here as a normal response and the transport never sends the body.
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		body, _ := session.ReadBody(resp.Body)
+		return fmt.Errorf("%w: %w", ErrETagMismatch, session.NewAPIError(resp.StatusCode, body))
+	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		body, _ := session.ReadBody(resp.Body)
+		return session.NewAPIError(resp.StatusCode, body)
 	}
 
 	return nil
@@ -376,130 +491,229 @@ func (dm *ZOSMFDatasetManager) UploadContent(request *UploadRequest) error {
 
 // DownloadContent downloads content from a dataset
 func (dm *ZOSMFDatasetManager) DownloadContent(request *DownloadRequest) (string, error) {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL using correct z/OSMF format
-	var apiURL string
-	if request.MemberName != "" {
-		// For members, use dataset(member) format
-		apiURL = session.GetBaseURL() + fmt.Sprintf("/restfiles/ds/%s(%s)", url.PathEscape(request.DatasetName), url.PathEscape(request.MemberName))
-	} else {
-		// For datasets, use the dataset endpoint directly (no /content suffix)
-		apiURL = session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(request.DatasetName))
+	body, err := dm.downloadBytes(request)
+	if err != nil {
+		return "", err
 	}
+	return string(body), nil
+}
 
-	// Add query parameters
-	params := url.Values{}
-	if request.Encoding != "" {
-		params.Set("encoding", request.Encoding)
+// DownloadWithETag downloads content along with its ETag, which can be passed
+// back as UploadRequest.IfMatch to avoid overwriting someone else's change
+func (dm *ZOSMFDatasetManager) DownloadWithETag(request *DownloadRequest) (string, string, error) {
+	resp, err := dm.openDownload(request, true)
+	if err != nil {
+		return "", "", err
 	}
-	if len(params) > 0 {
-		apiURL += "?" + params.Encode()
+	defer resp.Body.Close()
+
+	session, err := dm.getSession()
+	if err != nil {
+		return "", "", err
+	}
+	body, err := session.ReadBody(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
+	return string(body), resp.Header.Get("ETag"), nil
+}
+
+// downloadBytes downloads content from a dataset without string conversion
+func (dm *ZOSMFDatasetManager) downloadBytes(request *DownloadRequest) ([]byte, error) {
+	resp, err := dm.openDownload(request, false)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	// Read response body
+	session, err := dm.getSession()
+	if err != nil {
+		return nil, err
+	}
+	body, err := session.ReadBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	return body, nil
+}
+
+// DownloadContentTo streams dataset content into w without buffering it in
+// memory, returning the number of bytes written
+func (dm *ZOSMFDatasetManager) DownloadContentTo(request *DownloadRequest, w io.Writer) (int64, error) {
+	resp, err := dm.openDownload(request, false)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return 0, err
 	}
 	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to copy response body: %w", err)
 	}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	return n, nil
+}
+
+// openDownload issues the content GET and returns the successful response.
+// returnETag asks z/OSMF for an ETag header even on large content.
+// The caller must close the response body.
+func (dm *ZOSMFDatasetManager) openDownload(request *DownloadRequest, returnETag bool) (*http.Response, error) {
+	session, err := dm.getSession()
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	return string(body), nil
+	if err := validateDataType(request.DataType, request.Encoding); err != nil {
+		return nil, err
+	}
+	
+	// The codepage travels in X-IBM-Data-Type as for uploads. A member
+	// uses the dataset(member) form; a dataset has no /content suffix.
+	headers := map[string]string{
+		"X-IBM-Data-Type": dataTypeHeader(request.DataType, request.Encoding),
+	}
+	if request.RecordCount > 0 {
+		headers["X-IBM-Record-Range"] = fmt.Sprintf("%d,%d", request.StartRecord, request.RecordCount)
+	}
+	if returnETag {
+		headers["X-IBM-Return-Etag"] = "true"
+	}
+
+	path := datasetPath(request.DatasetName, request.MemberName)
+	return session.DoRawWithHeaders(context.Background(), "GET", path, nil, headers)
 }
 
 // ListMembers retrieves a list of members in a partitioned dataset
 func (dm *ZOSMFDatasetManager) ListMembers(datasetName string) (*MemberList, error) {
-	session := dm.session.(*profile.Session)
+	session, err := dm.getSession()
+	if err != nil {
+		return nil, err
+	}
 	
-	// Build URL using template
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(datasetName)) + MembersEndpoint
+	// Ask for ISPF statistics along with the names
+	var raw json.RawMessage
+	path := datasetPath(datasetName, "") + MembersEndpoint
+	headers := map[string]string{"X-IBM-Attributes": "base"}
+	if err := session.DoJSONWithHeaders(context.Background(), "GET", path, nil, &raw, headers); err != nil {
+		return nil, err
+	}
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
+	memberList, err := dm.parseMemberList(raw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	memberList.Truncated = memberList.MoreRows
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
+	return memberList, nil
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// parseMemberList decodes a z/OSMF member listing. Items normally carry
+// the attribute fields, but some systems return plain name entries, so
+// fall back to decoding the names only when the attributes don't fit.
+func (dm *ZOSMFDatasetManager) parseMemberList(data []byte) (*MemberList, error) {
+	var memberList MemberList
+	if err := profile.DecodeJSON(data, &memberList, dm.StrictJSON); err == nil {
+		return &memberList, nil
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	var raw struct {
+		Items        []json.RawMessage `json:"items"`
+		ReturnedRows int               `json:"returnedRows"`
+		MoreRows     bool              `json:"moreRows"`
+		JSONVersion  int               `json:"JSONversion"`
+	}
+	if err := profile.DecodeJSON(data, &raw, dm.StrictJSON); err != nil {
+		return nil, err
 	}
 
-	// Parse response
-	var memberList MemberList
-	if err := json.NewDecoder(resp.Body).Decode(&memberList); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	memberList = MemberList{
+		ReturnedRows: raw.ReturnedRows,
+		MoreRows:     raw.MoreRows,
+		JSONVersion:  raw.JSONVersion,
+	}
+	for _, item := range raw.Items {
+		var member DatasetMember
+		if err := profile.DecodeJSON(item, &member, dm.StrictJSON); err == nil {
+			memberList.Members = append(memberList.Members, member)
+			continue
+		}
+		var nameOnly struct {
+			Name string `json:"member"`
+		}
+		if err := profile.DecodeJSON(item, &nameOnly, dm.StrictJSON); err != nil {
+			return nil, err
+		}
+		memberList.Members = append(memberList.Members, DatasetMember{Name: nameOnly.Name})
 	}
 
 	return &memberList, nil
 }
 
-// GetMember retrieves information about a specific member
-func (dm *ZOSMFDatasetManager) GetMember(datasetName, memberName string) (*DatasetMember, error) {
-	session := dm.session.(*profile.Session)
+// memberExists checks for a single member using the member list pattern filter
+func (dm *ZOSMFDatasetManager) memberExists(datasetName, memberName string) (bool, error) {
+	session, err := dm.getSession()
+	if err != nil {
+		return false, err
+	}
 	
-	// Build URL using correct z/OSMF format: /zosmf/restfiles/ds/<dataset-name>(<member-name>)
-	apiURL := session.GetBaseURL() + fmt.Sprintf("/restfiles/ds/%s(%s)", url.PathEscape(datasetName), url.PathEscape(memberName))
+	params := url.Values{}
+	params.Set("pattern", memberName)
+	path := datasetPath(datasetName, "") + MembersEndpoint + "?" + params.Encode()
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
+	var raw json.RawMessage
+	if err := session.DoJSON(context.Background(), "GET", path, nil, &raw); err != nil {
+		return false, err
+	}
+
+	memberList, err := dm.parseMemberList(raw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return false, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	for _, member := range memberList.Members {
+		if strings.EqualFold(member.Name, memberName) {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// dataTypeHeader builds the X-IBM-Data-Type value for a transfer.
+// Go strings are already UTF-8, so UTF-8 keeps the server's default codepage.
+func dataTypeHeader(dataType DataType, encoding string) string {
+	if dataType != "" && dataType != DataTypeText {
+		return string(dataType)
 	}
-	defer resp.Body.Close()
+	if encoding == "" || strings.EqualFold(encoding, CodepageUTF8) {
+		return "text"
+	}
+	return "text;fileEncoding=" + encoding
+}
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// contentTypeFor picks the request Content-Type for a transfer mode
+func contentTypeFor(dataType DataType) string {
+	if dataType == DataTypeBinary || dataType == DataTypeRecord {
+		return "application/octet-stream"
+	}
+	return "text/plain"
+}
+
+// GetMember retrieves information about a specific member
+func (dm *ZOSMFDatasetManager) GetMember(datasetName, memberName string) (*DatasetMember, error) {
+	session, err := dm.getSession()
+	if err != nil {
+		return nil, err
+	}
+	
+	// URL format: /zosmf/restfiles/ds/<dataset-name>(<member-name>)
+	resp, err := session.DoRaw(context.Background(), "GET", datasetPath(datasetName, memberName), nil)
+	if err != nil {
+		return nil, err
 	}
+	resp.Body.Close()
 
 	// For member access, z/OSMF returns the member content as text, not JSON
 	// We'll create a DatasetMember with the member name since we can't get metadata
@@ -513,210 +727,409 @@ func (dm *ZOSMFDatasetManager) GetMember(datasetName, memberName string) (*Datas
 
 // DeleteMember deletes a member from a partitioned dataset
 func (dm *ZOSMFDatasetManager) DeleteMember(datasetName, memberName string) error {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL using correct z/OSMF format: /zosmf/restfiles/ds/<dataset-name>(<member-name>)
-	apiURL := session.GetBaseURL() + fmt.Sprintf("/restfiles/ds/%s(%s)", url.PathEscape(datasetName), url.PathEscape(memberName))
-
-	// Create request
-	req, err := http.NewRequest("DELETE", apiURL, nil)
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	
+	// URL format: /zosmf/restfiles/ds/<dataset-name>(<member-name>)
+	return session.DoJSON(context.Background(), "DELETE", datasetPath(datasetName, memberName), nil, nil)
 }
 
-// Exists checks if a dataset exists using the list API
+// Exists checks if a dataset exists using the list API. It asks for a
+// single name-and-volume entry: the catalog lists an exact dslevel match
+// before anything below it, so the first entry settles the question.
 func (dm *ZOSMFDatasetManager) Exists(name string) (bool, error) {
-	// Use the list API with the exact dataset name to check existence
-	dl, err := dm.ListDatasets(&DatasetFilter{Name: name})
+	name = strings.ToUpper(strings.TrimSpace(name))
+	dl, err := dm.ListDatasets(&DatasetFilter{Name: name, Attributes: AttributesVolume, Limit: 1})
 	if err != nil {
 		return false, err
 	}
 	
-	// Check if the dataset was found in the results
-	for _, ds := range dl.Datasets {
-		if ds.Name == name {
-			return true, nil
-		}
-	}
-	
-	return false, nil
+	return len(dl.Datasets) > 0 && dl.Datasets[0].Name == name, nil
 }
 
 // CopySequentialDataset copies a sequential dataset using the z/OSMF REST API
 // This function handles copying entire datasets (not members)
 func (dm *ZOSMFDatasetManager) CopySequentialDataset(sourceName, targetName string) error {
-	session := dm.session.(*profile.Session)
+	session, err := dm.getSession()
+	if err != nil {
+		return err
+	}
 	
-	// Build URL to the target dataset (z/OSMF format: PUT to target with source in body)
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(targetName))
-
 	// Prepare request body according to z/OSMF API specification for dataset copy
 	requestBody := map[string]interface{}{
 		"request": "copy",
 		"from-dataset": map[string]string{
-			"dsn": sourceName,
+			"dsn": strings.ToUpper(sourceName),
 		},
 	}
 
-	// Serialize request body
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+	// PUT to the target dataset with the source in the body, not POST to source/copy
+	return session.DoJSON(context.Background(), "PUT", datasetPath(targetName, ""), requestBody, nil)
+}
+
+// CopyMember copies a member from one partitioned dataset to another using the z/OSMF REST API
+// sourceName should be in format "DATASET.NAME" and sourceMember is the member name
+// targetName should be in format "DATASET.NAME" and targetMember is the member name
+func (dm *ZOSMFDatasetManager) CopyMember(sourceName, sourceMember, targetName, targetMember string) error {
+	for _, name := range []string{sourceName, targetName} {
+		if _, err := NormalizeDatasetName(name); err != nil {
+			return fmt.Errorf("invalid dataset name: %w", err)
+		}
+	}
+	for _, member := range []string{sourceMember, targetMember} {
+		if _, err := NormalizeMemberName(member); err != nil {
+			return fmt.Errorf("invalid member name: %w", err)
+		}
 	}
 
-	// Create request (PUT to target dataset, not POST to source/copy)
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	// PUT to the target member: /restfiles/ds/<target-dataset>(<target-member>)
+	return dm.copyDataset(targetName, targetMember, map[string]string{
+		"dsn":    strings.ToUpper(sourceName),
+		"member": strings.ToUpper(sourceMember),
+	}, nil)
+}
+
+// CopyDataset copies a dataset (sequential, or a whole PDS) to targetName
+func (dm *ZOSMFDatasetManager) CopyDataset(sourceName, targetName string) error {
+	return dm.CopyDatasetWithOptions(sourceName, targetName, nil)
+}
+
+// CopyDatasetWithOptions copies sourceName to targetName with the z/OSMF
+// replace, enq and alias options. Set SourceMember and/or TargetMember to
+// copy a single member, or a sequential dataset into a PDS member.
+func (dm *ZOSMFDatasetManager) CopyDatasetWithOptions(sourceName, targetName string, options *CopyOptions) error {
+	if options == nil {
+		options = &CopyOptions{}
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	for _, name := range []string{sourceName, targetName} {
+		if _, err := NormalizeDatasetName(name); err != nil {
+			return fmt.Errorf("invalid dataset name: %w", err)
+		}
+	}
+	for _, member := range []string{options.SourceMember, options.TargetMember} {
+		if member == "" {
+			continue
+		}
+		if _, err := NormalizeMemberName(member); err != nil {
+			return fmt.Errorf("invalid member name: %w", err)
+		}
+	}
+	switch options.Enqueue {
+	case "", EnqueueShared, EnqueueSharedWrite, EnqueueExclusive:
+	default:
+		return fmt.Errorf("invalid enqueue type %q", options.Enqueue)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+	fromDataset := map[string]string{
+		"dsn": strings.ToUpper(sourceName),
 	}
-	defer resp.Body.Close()
+	if options.SourceMember != "" {
+		fromDataset["member"] = strings.ToUpper(options.SourceMember)
+	}
+	return dm.copyDataset(targetName, options.TargetMember, fromDataset, options)
+}
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+// copyDataset issues a z/OSMF copy into targetName, or into targetMember of it
+// when set, from the dataset (and optional member) described by fromDataset.
+// options may be nil for a plain copy.
+func (dm *ZOSMFDatasetManager) copyDataset(targetName, targetMember string, fromDataset map[string]string, options *CopyOptions) error {
+	session, err := dm.getSession()
+	if err != nil {
+		return err
+	}
+	
+	requestBody := map[string]interface{}{
+		"request":      "copy",
+		"from-dataset": fromDataset,
+	}
+	if options != nil {
+		if options.Replace {
+			requestBody["replace"] = true
+		}
+		if options.Enqueue != "" {
+			requestBody["enq"] = string(options.Enqueue)
+		}
+		if options.Alias {
+			requestBody["alias"] = true
+		}
 	}
 
-	return nil
+	// PUT to the copy target, or to its member when set
+	return session.DoJSON(context.Background(), "PUT", datasetPath(targetName, targetMember), requestBody, nil)
 }
 
-// CopyMember copies a member from one partitioned dataset to another using the z/OSMF REST API
-// sourceName should be in format "DATASET.NAME" and sourceMember is the member name
-// targetName should be in format "DATASET.NAME" and targetMember is the member name
-func (dm *ZOSMFDatasetManager) CopyMember(sourceName, sourceMember, targetName, targetMember string) error {
-	session := dm.session.(*profile.Session)
+// RenameDataset renames a dataset using the z/OSMF REST API
+func (dm *ZOSMFDatasetManager) RenameDataset(oldName, newName string) error {
+	session, err := dm.getSession()
+	if err != nil {
+		return err
+	}
 	
-	// Build URL to the target member using correct z/OSMF format: /zosmf/restfiles/ds/<target-dataset>(<target-member>)
-	apiURL := session.GetBaseURL() + fmt.Sprintf("/restfiles/ds/%s(%s)", url.PathEscape(targetName), url.PathEscape(targetMember))
-
-	// Prepare request body according to z/OSMF API specification for member copy
+	// Prepare request body according to z/OSMF API specification
 	requestBody := map[string]interface{}{
-		"request": "copy",
+		"request": "rename",
 		"from-dataset": map[string]string{
-			"dsn":    sourceName,
-			"member": sourceMember,
+			"dsn": strings.ToUpper(oldName),
 		},
 	}
 
-	// Serialize request body
-	jsonBody, err := json.Marshal(requestBody)
+	// PUT to the new dataset name with the source in the body
+	return session.DoJSON(context.Background(), "PUT", datasetPath(newName, ""), requestBody, nil)
+}
+
+// RenameMember renames a member within a partitioned dataset. It fails with
+// ErrMemberExists when newMember is already there rather than replacing it.
+func (dm *ZOSMFDatasetManager) RenameMember(datasetName, oldMember, newMember string) error {
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return err
 	}
 
-	// Create request (PUT to target member)
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(jsonBody))
+	datasetName, err = NormalizeDatasetName(datasetName)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("invalid dataset name: %w", err)
 	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	oldMember, err = NormalizeMemberName(oldMember)
+	if err != nil {
+		return fmt.Errorf("invalid member name: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	newMember, err = NormalizeMemberName(newMember)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return fmt.Errorf("invalid member name: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	exists, err := dm.memberExists(datasetName, newMember)
+	if err != nil {
+		return fmt.Errorf("failed to check member existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s(%s)", ErrMemberExists, datasetName, newMember)
 	}
 
-	return nil
-}
-
-// RenameDataset renames a dataset using the z/OSMF REST API
-func (dm *ZOSMFDatasetManager) RenameDataset(oldName, newName string) error {
-	session := dm.session.(*profile.Session)
-	
-	// Build URL to the new dataset name (z/OSMF format: PUT to target with source in body)
-	apiURL := session.GetBaseURL() + fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(newName))
-
-	// Prepare request body according to z/OSMF API specification
+	// PUT to the new member name with the old one in the body
 	requestBody := map[string]interface{}{
 		"request": "rename",
 		"from-dataset": map[string]string{
-			"dsn": oldName,
+			"dsn":    datasetName,
+			"member": oldMember,
 		},
 	}
+	return session.DoJSON(context.Background(), "PUT", datasetPath(datasetName, newMember), requestBody, nil)
+}
 
-	// Serialize request body
-	jsonBody, err := json.Marshal(requestBody)
+// CreateVSAM defines a VSAM cluster with IDCAMS DEFINE CLUSTER, run through
+// the z/OSMF AMS service. The request is checked with
+// ValidateCreateVSAMRequest first.
+func (dm *ZOSMFDatasetManager) CreateVSAM(request *CreateVSAMRequest) error {
+	if err := ValidateCreateVSAMRequest(request); err != nil {
+		return err
+	}
+	response, err := dm.InvokeAMS(defineClusterStatements(request))
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return err
+	}
+	if !response.HasReturnCode {
+		return fmt.Errorf("DEFINE CLUSTER reported no condition code: %s", strings.Join(response.Output, "\n"))
+	}
+	if response.ReturnCode > 4 {
+		return fmt.Errorf("DEFINE CLUSTER failed with condition code %d: %s",
+			response.ReturnCode, strings.Join(response.Output, "\n"))
+	}
+	return nil
+}
+
+// vsamSpaceUnits maps space units to their IDCAMS keywords
+var vsamSpaceUnits = map[SpaceUnit]string{
+	SpaceUnitTracks:    "TRACKS",
+	SpaceUnitCylinders: "CYLINDERS",
+	SpaceUnitKB:        "KILOBYTES",
+	SpaceUnitMB:        "MEGABYTES",
+}
+
+// defineClusterStatements builds a DEFINE CLUSTER command, one parameter per
+// line with IDCAMS "-" continuations so no line nears the 255 byte limit
+func defineClusterStatements(request *CreateVSAMRequest) []string {
+	organization := request.Organization
+	if organization == "" {
+		organization = VSAMKSDS
+	}
+
+	params := []string{
+		fmt.Sprintf("NAME('%s')", strings.ToUpper(strings.TrimSpace(request.Name))),
+		string(organization),
+	}
+	if organization == VSAMKSDS {
+		params = append(params, fmt.Sprintf("KEYS(%d %d)", request.KeyLength, request.KeyOffset))
 	}
+	if request.MaxRecordSize > 0 {
+		average := request.AverageRecordSize
+		if average == 0 {
+			average = request.MaxRecordSize
+		}
+		params = append(params, fmt.Sprintf("RECORDSIZE(%d %d)", average, request.MaxRecordSize))
+	}
+	if request.CISize > 0 {
+		params = append(params, fmt.Sprintf("CONTROLINTERVALSIZE(%d)", request.CISize))
+	}
+	params = append(params, fmt.Sprintf("%s(%d %d)", vsamSpaceUnits[request.Space.Unit], request.Space.Primary, request.Space.Secondary))
+	if len(request.Volumes) > 0 {
+		params = append(params, fmt.Sprintf("VOLUMES(%s)", strings.ToUpper(strings.Join(request.Volumes, " "))))
+	}
+	for _, class := range []struct{ keyword, value string }{
+		{"STORAGECLASS", request.StorageClass},
+		{"MANAGEMENTCLASS", request.ManagementClass},
+		{"DATACLASS", request.DataClass},
+	} {
+		if class.value != "" {
+			params = append(params, fmt.Sprintf("%s(%s)", class.keyword, strings.ToUpper(class.value)))
+		}
+	}
+
+	statements := []string{"DEFINE CLUSTER -", "(" + params[0] + " -"}
+	for _, param := range params[1:] {
+		statements = append(statements, param+" -")
+	}
+	return append(statements, ")")
+}
 
-	// Create request (PUT to target dataset, not PUT to source/rename)
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(jsonBody))
+// maxAMSStatementLength is the longest input line the AMS service accepts
+const maxAMSStatementLength = 255
+
+// amsConditionCode finds the condition code IDCAMS reports at the end of a
+// run, or per command when the summary line is missing
+var amsConditionCode = regexp.MustCompile(`(?:MAXIMUM|HIGHEST) CONDITION CODE WAS (\d+)`)
+
+// InvokeAMS runs Access Method Services (IDCAMS) statements through the
+// z/OSMF AMS service, e.g. to define, delete or REPRO VSAM clusters. Each
+// statement is one input line of at most 255 characters; continue long
+// commands with a trailing "-". The IDCAMS listing and its condition code
+// come back in the response; a condition code above 4 is not an error here,
+// so check ReturnCode, and HasReturnCode for a listing that carried none.
+func (dm *ZOSMFDatasetManager) InvokeAMS(statements []string) (*AMSResponse, error) {
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no AMS statements to run")
+	}
+	for _, statement := range statements {
+		if len(statement) > maxAMSStatementLength {
+			return nil, fmt.Errorf("AMS statement longer than %d characters: %.40s...", maxAMSStatementLength, statement)
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	requestBody := map[string]interface{}{
+		"input": statements,
+	}
+	var raw json.RawMessage
+	if err := session.DoJSON(context.Background(), "PUT", AMSEndpoint, requestBody, &raw); err != nil {
+		return nil, err
+	}
+	return parseAMSResponse(raw), nil
+}
+
+// parseAMSResponse reads the IDCAMS listing from the service's reply, which
+// carries it either as an array of lines or as one string
+func parseAMSResponse(raw json.RawMessage) *AMSResponse {
+	var body struct {
+		Output json.RawMessage `json:"output"`
+	}
+	output := raw
+	if json.Unmarshal(raw, &body) == nil && len(body.Output) > 0 {
+		output = body.Output
+	}
+
+	response := &AMSResponse{}
+	var lines []string
+	var text string
+	switch {
+	case json.Unmarshal(output, &lines) == nil:
+		response.Output = lines
+	case json.Unmarshal(output, &text) == nil:
+		response.Output = strings.Split(strings.TrimRight(text, "\n"), "\n")
+	}
+
+	for _, line := range response.Output {
+		if match := amsConditionCode.FindStringSubmatch(line); match != nil {
+			code, _ := strconv.Atoi(match[1])
+			if !response.HasReturnCode || code > response.ReturnCode {
+				response.ReturnCode = code
+			}
+			response.HasReturnCode = true
+		}
+	}
+	return response
+}
+
+// MigrateDataset migrates a dataset to HSM storage (HMIGRATE)
+func (dm *ZOSMFDatasetManager) MigrateDataset(name string) error {
+	requestBody := map[string]interface{}{
+		"request": "hmigrate",
+	}
+	return dm.datasetUtility(context.Background(), name, requestBody)
+}
+
+// RecallDataset recalls a migrated dataset (HRECALL). With wait=true the
+// request doesn't return until the recall has finished.
+func (dm *ZOSMFDatasetManager) RecallDataset(name string, wait bool) error {
+	return dm.recallDataset(context.Background(), name, wait)
+}
+
+// recallDataset is RecallDataset bounded by ctx
+func (dm *ZOSMFDatasetManager) recallDataset(ctx context.Context, name string, wait bool) error {
+	requestBody := map[string]interface{}{
+		"request": "hrecall",
+	}
+	if wait {
+		requestBody["wait"] = true
+	}
+	return dm.datasetUtility(ctx, name, requestBody)
+}
+
+// datasetUtility sends a z/OSMF dataset utility request (PUT with a JSON body)
+func (dm *ZOSMFDatasetManager) datasetUtility(ctx context.Context, name string, requestBody map[string]interface{}) error {
+	session, err := dm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	return session.DoJSON(ctx, "PUT", datasetPath(name, ""), requestBody, nil)
+}
+
+// datasetURL builds the URL of a dataset, or of a member when member is set
+func datasetURL(session *profile.Session, name, member string) string {
+	return session.GetBaseURL() + datasetPath(name, member)
+}
+
+// datasetPath is datasetURL without the base URL. Names are uppercased
+// since z/OS dataset and member names always are.
+func datasetPath(name, member string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if member == "" {
+		return fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(name))
 	}
+	member = strings.ToUpper(strings.TrimSpace(member))
+	return fmt.Sprintf(DatasetByNameEndpoint, url.PathEscape(name)+"("+url.PathEscape(member)+")")
+}
 
-	return nil
+// datasetOnVolumePath builds the path of a dataset on a specific volume
+func datasetOnVolumePath(name, volume string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	volume = strings.ToUpper(strings.TrimSpace(volume))
+	return fmt.Sprintf(DatasetOnVolumeEndpoint, url.PathEscape(volume), url.PathEscape(name))
 }
 
 // CloseDatasetManager closes the dataset manager and its underlying HTTP connections
 func (dm *ZOSMFDatasetManager) CloseDatasetManager() error {
-	session := dm.session.(*profile.Session)
+	session := dm.session
+	if session == nil {
+		return nil
+	}
 	
 	// Close idle connections in the HTTP client
 	if client := session.GetHTTPClient(); client != nil {
@@ -1,5 +1,34 @@
 package datasets
 
+import (
+	"errors"
+	"time"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// ErrMemberExists is returned when a non-replacing upload targets an existing member
+var ErrMemberExists = errors.New("member already exists")
+
+// ErrETagMismatch is returned when a conditional upload's ETag is stale,
+// meaning someone else changed the content since it was downloaded
+var ErrETagMismatch = errors.New("content changed since it was read")
+
+// ErrDatasetNotFound is returned when a lookup matches no dataset
+var ErrDatasetNotFound = errors.New("dataset not found")
+
+// ErrDatasetInUse is returned when a dataset can't be changed because
+// another job or user has it allocated (enqueued)
+var ErrDatasetInUse = errors.New("dataset is in use")
+
+// ErrRecallTimeout is returned when an HSM recall doesn't finish within the
+// manager's RecallTimeout, or finishes with the dataset still migrated
+var ErrRecallTimeout = errors.New("dataset recall did not complete")
+
+// DefaultRecallTimeout is how long a recall is waited for when the manager's
+// RecallTimeout is zero. HSM recalls from tape can take minutes.
+const DefaultRecallTimeout = 5 * time.Minute
+
 // DatasetType represents the type of dataset
 type DatasetType string
 
@@ -30,6 +59,34 @@ const (
 	RecordFormatUndefined RecordFormat = "U"
 )
 
+// DataType represents the z/OSMF transfer mode (X-IBM-Data-Type)
+type DataType string
+
+const (
+	DataTypeText   DataType = "text"   // Codepage conversion and record delimiters
+	DataTypeBinary DataType = "binary" // Raw bytes, no conversion or newline handling
+	DataTypeRecord DataType = "record" // Records prefixed with a 4-byte length
+)
+
+// Common host codepages for the Encoding field of uploads and downloads,
+// sent as X-IBM-Data-Type: text;fileEncoding=<codepage>
+const (
+	CodepageIBM1047  = "IBM-1047"  // z/OS Open Systems Latin-1, the usual USS and ISPF default
+	CodepageIBM037   = "IBM-037"   // US/Canada EBCDIC
+	CodepageISO88591 = "ISO8859-1" // Data stored in ASCII on the host
+	CodepageUTF8     = "UTF-8"     // No fileEncoding; the server's default conversion applies
+)
+
+// ListAttributes selects how much z/OSMF returns per dataset when listing
+// (X-IBM-Attributes)
+type ListAttributes string
+
+const (
+	AttributesBase   ListAttributes = "base"   // All attributes: dsorg, DCB, space, dates
+	AttributesVolume ListAttributes = "vol"    // Name and volume only; fast
+	AttributesName   ListAttributes = "dsname" // Name only; fastest
+)
+
 // RecordLength represents the record length
 type RecordLength int
 
@@ -72,6 +129,10 @@ type Dataset struct {
 	SpaceUnit    string `json:"spacu,omitempty"`  // Space unit
 	Used         string `json:"used,omitempty"`   // Used percentage
 	VolumeList   string `json:"vols,omitempty"`   // Volume list
+
+	// Referenced is RefDate parsed by ListDatasets; zero when z/OSMF
+	// reported no usable date (never referenced, migrated, or non-base listing)
+	Referenced time.Time `json:"-"`
 }
 
 // Space represents space allocation parameters
@@ -82,9 +143,22 @@ type Space struct {
 	Directory int       `json:"directory,omitempty"` // For PDS
 }
 
-// DatasetMember represents a member in a partitioned dataset
+// DatasetMember represents a member in a partitioned dataset.
+// Attribute fields are only populated when z/OSMF returns them
+// (X-IBM-Attributes: base); a plain name listing leaves them zero.
 type DatasetMember struct {
-	Name string `json:"member"` // Member name
+	Name            string `json:"member"`           // Member name
+	Version         int    `json:"vers,omitempty"`   // ISPF version number
+	ModLevel        int    `json:"mod,omitempty"`    // ISPF modification level
+	CreatedDate     string `json:"c4date,omitempty"` // Creation date (yyyy/mm/dd)
+	ModifiedDate    string `json:"m4date,omitempty"` // Last change date (yyyy/mm/dd)
+	ModifiedTime    string `json:"mtime,omitempty"`  // Last change time (hh:mm)
+	ModifiedSeconds string `json:"msec,omitempty"`   // Last change seconds
+	CurrentRecords  int    `json:"cnorc,omitempty"`  // Current number of records
+	InitialRecords  int    `json:"inorc,omitempty"`  // Initial number of records
+	ModifiedRecords int    `json:"mnorc,omitempty"`  // Number of changed records
+	User            string `json:"user,omitempty"`   // Userid of last change
+	SCLM            string `json:"sclm,omitempty"`   // SCLM managed flag
 }
 
 // DatasetList represents a list of datasets
@@ -93,13 +167,22 @@ type DatasetList struct {
 	ReturnedRows int       `json:"returnedRows"`    // Rows returned
 	MoreRows     bool      `json:"moreRows"`        // More data available
 	JSONVersion  int       `json:"JSONversion"`     // API version
+
+	// Truncated is set when the listing hit X-IBM-Max-Items and more rows exist
+	Truncated bool `json:"-"`
+
+	lastName string // Last name z/OSMF returned, before any Type filtering
 }
 
 // MemberList represents a list of members in a PDS
 type MemberList struct {
 	Members      []DatasetMember `json:"items"`           // Member array
 	ReturnedRows int             `json:"returnedRows"`    // Rows returned
+	MoreRows     bool            `json:"moreRows"`        // More data available
 	JSONVersion  int             `json:"JSONversion"`     // API version
+
+	// Truncated is set when the listing hit X-IBM-Max-Items and more rows exist
+	Truncated bool `json:"-"`
 }
 
 // CreateDatasetRequest represents a request to create a dataset
@@ -112,22 +195,85 @@ type CreateDatasetRequest struct {
 	RecordLength RecordLength `json:"recordLength,omitempty"`
 	BlockSize    BlockSize   `json:"blockSize,omitempty"`
 	Directory    int         `json:"directory,omitempty"`
+
+	// SkipValidation sends the request without ValidateCreateDatasetRequest,
+	// for allocations the local checks don't understand
+	SkipValidation bool `json:"-"`
 }
 
-// UploadRequest represents a request to upload content
+// VSAMOrganization is the kind of VSAM cluster CreateVSAM defines
+type VSAMOrganization string
+
+const (
+	VSAMKSDS VSAMOrganization = "INDEXED"    // Key-sequenced
+	VSAMESDS VSAMOrganization = "NONINDEXED" // Entry-sequenced
+	VSAMRRDS VSAMOrganization = "NUMBERED"   // Relative record
+	VSAMLDS  VSAMOrganization = "LINEAR"     // Linear (no records)
+)
+
+// CreateVSAMRequest describes a VSAM cluster for CreateVSAM, which defines
+// it with IDCAMS DEFINE CLUSTER. Space units are TRK, CYL, KB or MB.
+type CreateVSAMRequest struct {
+	Name         string           `json:"name"`
+	Organization VSAMOrganization `json:"organization,omitempty"` // Defaults to VSAMKSDS
+	KeyLength    int              `json:"keyLength,omitempty"`    // KSDS only, 1-255
+	KeyOffset    int              `json:"keyOffset,omitempty"`    // KSDS only
+
+	// Record sizes in bytes; zero leaves them to IDCAMS. AverageRecordSize
+	// defaults to MaxRecordSize. Not allowed for linear clusters.
+	AverageRecordSize int `json:"averageRecordSize,omitempty"`
+	MaxRecordSize     int `json:"maxRecordSize,omitempty"`
+
+	// CISize is the control interval size; zero lets VSAM choose
+	CISize int `json:"ciSize,omitempty"`
+
+	Space           Space    `json:"space"`
+	Volumes         []string `json:"volumes,omitempty"` // Omit when SMS picks the volume
+	StorageClass    string   `json:"storageClass,omitempty"`
+	ManagementClass string   `json:"managementClass,omitempty"`
+	DataClass       string   `json:"dataClass,omitempty"`
+}
+
+// AMSResponse is the result of InvokeAMS
+type AMSResponse struct {
+	Output        []string // IDCAMS listing, one line per entry
+	ReturnCode    int      // Highest condition code IDCAMS reported
+	HasReturnCode bool     // False when the listing carried no condition code
+}
+
+// UploadRequest represents a request to upload content.
+// Encoding names the host codepage (e.g. IBM-1047); empty or UTF-8 uses the
+// z/OSMF default conversion. Replace=false refuses to overwrite an existing member.
 type UploadRequest struct {
-	DatasetName string `json:"datasetName"`
-	MemberName  string `json:"memberName,omitempty"` // For PDS members
-	Content     string `json:"content"`
-	Encoding    string `json:"encoding,omitempty"`
-	Replace     bool   `json:"replace,omitempty"`
+	DatasetName string   `json:"datasetName"`
+	MemberName  string   `json:"memberName,omitempty"` // For PDS members
+	Content     string   `json:"content"`
+	Data        []byte   `json:"-"`                    // Raw content, takes precedence over Content
+	Encoding    string   `json:"encoding,omitempty"`
+	DataType    DataType `json:"dataType,omitempty"`   // Defaults to text
+	Replace     bool     `json:"replace,omitempty"`
+
+	// ExpectContinue sends "Expect: 100-continue" so z/OSMF can reject the
+	// upload (bad credentials, no space) before the body is transmitted
+	ExpectContinue bool `json:"-"`
+
+	// IfMatch makes the write conditional on the ETag from an earlier
+	// download; a stale ETag fails with ErrETagMismatch
+	IfMatch string `json:"-"`
 }
 
-// DownloadRequest represents a request to download content
+// DownloadRequest represents a request to download content.
+// Encoding names the host codepage (e.g. IBM-1047) to convert from; empty
+// or UTF-8 uses the z/OSMF default conversion. RecordCount > 0 limits the
+// download to that many records starting at the zero-based StartRecord
+// (sent as X-IBM-Record-Range).
 type DownloadRequest struct {
-	DatasetName string `json:"datasetName"`
-	MemberName  string `json:"memberName,omitempty"` // For PDS members
-	Encoding    string `json:"encoding,omitempty"`
+	DatasetName string   `json:"datasetName"`
+	MemberName  string   `json:"memberName,omitempty"` // For PDS members
+	Encoding    string   `json:"encoding,omitempty"`   // Host codepage, e.g. CodepageIBM1047
+	DataType    DataType `json:"dataType,omitempty"`   // Defaults to text
+	StartRecord int      `json:"startRecord,omitempty"`
+	RecordCount int      `json:"recordCount,omitempty"`
 }
 
 // DatasetFilter represents filters for dataset queries
@@ -136,9 +282,98 @@ type DatasetFilter struct {
 	Type   string `json:"type,omitempty"`
 	Volume string `json:"volume,omitempty"`
 	Owner  string `json:"owner,omitempty"`
-	Limit  int    `json:"limit,omitempty"`
+	Limit  int    `json:"limit,omitempty"` // X-IBM-Max-Items; zero means DefaultListLimit
+	Start  string `json:"start,omitempty"` // First dataset name to return, for paging
+
+	// Unlimited asks ListDatasets for every matching dataset in one
+	// response (X-IBM-Max-Items: 0) when Limit is zero. Prefer
+	// ListDatasetsAll, which pages instead.
+	Unlimited bool `json:"unlimited,omitempty"`
+
+	// Attributes picks what each listed dataset carries; empty means
+	// AttributesBase. Datasets listed with less only have those fields set.
+	Attributes ListAttributes `json:"attributes,omitempty"`
+
+	// MaxResults caps how many datasets ListDatasetsAll and ListDatasetsPages
+	// collect across all pages. Zero means no cap.
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// AllDatasets lists every dataset the user may see (dslevel=**) when
+	// neither Name nor Volume is set. Without it such a filter lists only
+	// the user's own datasets (dslevel=<user>.*). A catalog-wide search can
+	// be slow, so pair it with a Limit or MaxResults.
+	AllDatasets bool `json:"allDatasets,omitempty"`
+}
+
+// DefaultListLimit is the X-IBM-Max-Items ListDatasets sends when the
+// filter sets neither Limit nor Unlimited. Truncated reports a cut listing.
+const DefaultListLimit = 1000
+
+// DefaultListPageSize is the page size ListDatasetsPages uses when the filter has no Limit
+const DefaultListPageSize = 1000
+
+// SearchOptions controls SearchMembers
+type SearchOptions struct {
+	Regex           bool // Treat the pattern as a regular expression
+	CaseInsensitive bool // Ignore case when matching
+	MaxConcurrency  int  // Members downloaded in parallel (default 4)
+}
+
+// DownloadMembersOptions controls DownloadAllMembersWithOptions
+type DownloadMembersOptions struct {
+	Extension      string   // Appended to each member's file name, e.g. ".jcl"
+	DataType       DataType // DataTypeText (default) or DataTypeBinary
+	MaxConcurrency int      // Members downloaded in parallel (default 4)
+}
+
+// LineMatch is a single matching line in a member
+type LineMatch struct {
+	Line int    // One-based line number
+	Text string // Full text of the line
+}
+
+// MemberMatch holds the search results for one member. Err is set when
+// the member couldn't be read; the rest of the search carries on.
+type MemberMatch struct {
+	Member  string
+	Matches []LineMatch
+	Err     error
+}
+
+// EnqueueType is the serialization z/OSMF obtains on a copy target
+type EnqueueType string
+
+const (
+	EnqueueShared      EnqueueType = "SHR"  // Shared access
+	EnqueueSharedWrite EnqueueType = "SHRW" // Shared access for writing
+	EnqueueExclusive   EnqueueType = "EXCL" // Exclusive access
+)
+
+// CopyOptions tunes CopyDatasetWithOptions
+type CopyOptions struct {
+	SourceMember string      // Copy only this member of the source PDS
+	TargetMember string      // Copy into this member of the target PDS (also for sequential sources)
+	Replace      bool        // Overwrite like-named members or an existing target
+	Enqueue      EnqueueType // Serialization on the target, z/OSMF defaults to SHRW
+	Alias        bool        // Copy alias entries along with PDS members
+}
+
+// CompressOptions controls the IEBCOPY job CompressDatasetWithOptions submits
+type CompressOptions struct {
+	JobCard      string        // JOB statement, defaults to DefaultCompressJobCard
+	Timeout      time.Duration // How long to wait for the job, defaults to 5 minutes
+	PollInterval time.Duration // How often to poll the job, defaults to 2 seconds
+}
+
+// DeleteOptions tunes DeleteDatasetWithOptions
+type DeleteOptions struct {
+	Volume         string // Delete the copy on this volume (-(volser) form) rather than the cataloged one
+	RecallMigrated bool   // Recall the dataset from HSM and wait before deleting it; ignored with Volume
 }
 
+// DefaultCompressJobCard is used when CompressOptions.JobCard is empty
+const DefaultCompressJobCard = "//COMPRESS JOB ,'PDS COMPRESS'"
+
 // DatasetManager interface for dataset operations
 type DatasetManager interface {
 	// Basic operations
@@ -166,5 +401,12 @@ type DatasetManager interface {
 
 // ZOSMFDatasetManager implements DatasetManager for ZOSMF
 type ZOSMFDatasetManager struct {
-	session interface{} // Will be *profile.Session
+	session *profile.Session
+
+	// StrictJSON decodes responses in profile.DecodeJSON's strict mode
+	StrictJSON bool
+
+	// RecallTimeout bounds how long DownloadTextEnsureRecalled waits for an
+	// HSM recall. Zero means DefaultRecallTimeout.
+	RecallTimeout time.Duration
 }
@@ -1,13 +1,122 @@
 package jobs
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
 )
 
+// ListJobsPages lists jobs pageSize at a time. z/OSMF has no continuation
+// for job listings, so each round raises max-jobs by pageSize and delivers
+// only jobs not seen before; it ends when a listing comes back short.
+// filter.MaxJobs, when set, caps the total. fn can return false to stop.
+func (jm *ZOSMFJobManager) ListJobsPages(filter *JobFilter, pageSize int, fn func(page []Job) bool) error {
+	if pageSize <= 0 {
+		pageSize = DefaultMaxJobs
+	}
+	pageFilter := JobFilter{}
+	if filter != nil {
+		pageFilter = *filter
+	}
+	limit := pageFilter.MaxJobs
+
+	// Apply the submission window per page so z/OSMF's own count still
+	// drives when to stop
+	window := pageFilter
+	pageFilter.SubmittedAfter = time.Time{}
+	pageFilter.SubmittedBefore = time.Time{}
+	if window.filtersSubmitted() {
+		pageFilter.ExecData = true
+	}
+
+	seen := make(map[string]bool)
+	for maxJobs := pageSize; ; maxJobs += pageSize {
+		if limit > 0 && maxJobs > limit {
+			maxJobs = limit
+		}
+		pageFilter.MaxJobs = maxJobs
+
+		jobList, err := jm.ListJobs(&pageFilter)
+		if err != nil {
+			return err
+		}
+
+		var page []Job
+		for _, job := range jobList.Jobs {
+			if key := jobKey(job); !seen[key] {
+				seen[key] = true
+				page = append(page, job)
+			}
+		}
+		fresh := len(page)
+		if window.filtersSubmitted() {
+			page = window.matchSubmitted(page)
+		}
+		if len(page) > 0 && !fn(page) {
+			return nil
+		}
+		if len(jobList.Jobs) < maxJobs || maxJobs == limit || fresh == 0 {
+			return nil
+		}
+	}
+}
+
+// execTimeLayouts are the exec-data timestamp formats z/OSMF has been seen to return
+var execTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.000", "2006-01-02T15:04:05"}
+
+// parseExecTime parses an exec-data timestamp, returning zero when it can't
+func parseExecTime(value string) time.Time {
+	for _, layout := range execTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// populateExecTimes parses each job's exec-data timestamps
+func populateExecTimes(jobs []Job) {
+	for i := range jobs {
+		jobs[i].Submitted = parseExecTime(jobs[i].ExecSubmitted)
+		jobs[i].Started = parseExecTime(jobs[i].ExecStarted)
+		jobs[i].Ended = parseExecTime(jobs[i].ExecEnded)
+	}
+}
+
+// filtersSubmitted reports whether the filter has a submission window
+func (f *JobFilter) filtersSubmitted() bool {
+	return !f.SubmittedAfter.IsZero() || !f.SubmittedBefore.IsZero()
+}
+
+// matchSubmitted keeps the jobs submitted inside the filter's window
+func (f *JobFilter) matchSubmitted(jobs []Job) []Job {
+	var matched []Job
+	for _, job := range jobs {
+		if job.Submitted.IsZero() {
+			continue
+		}
+		if !f.SubmittedAfter.IsZero() && job.Submitted.Before(f.SubmittedAfter) {
+			continue
+		}
+		if !f.SubmittedBefore.IsZero() && !job.Submitted.Before(f.SubmittedBefore) {
+			continue
+		}
+		matched = append(matched, job)
+	}
+	return matched
+}
+
 // parseCorrelator parses "jobname:jobid" into separate parts
 func parseCorrelator(correlator string) (jobName, jobID string, err error) {
 	parts := strings.Split(correlator, ":")
@@ -60,6 +169,25 @@ func (jm *ZOSMFJobManager) SubmitJobStatement(jclStatement string) (*SubmitJobRe
 	return jm.SubmitJob(request)
 }
 
+// SubmitJobStatementWithSymbols submits JCL with values for its &SYMBOL references
+func (jm *ZOSMFJobManager) SubmitJobStatementWithSymbols(jcl string, symbols map[string]string) (*SubmitJobResponse, error) {
+	request := &SubmitJobRequest{
+		JobStatement: jcl,
+		Symbols:      symbols,
+	}
+	return jm.SubmitJob(request)
+}
+
+// SubmitJobText submits JCL held in memory through the internal reader
+func (jm *ZOSMFJobManager) SubmitJobText(jcl string) (*SubmitJobResponse, error) {
+	return jm.SubmitJCL(strings.NewReader(jcl), nil)
+}
+
+// SubmitJobBytes submits JCL bytes held in memory through the internal reader
+func (jm *ZOSMFJobManager) SubmitJobBytes(jcl []byte) (*SubmitJobResponse, error) {
+	return jm.SubmitJCL(bytes.NewReader(jcl), nil)
+}
+
 // SubmitJobFromDataset submits a job from a dataset
 func (jm *ZOSMFJobManager) SubmitJobFromDataset(dataset string, volume string) (*SubmitJobResponse, error) {
 	// Ensure dataset name is properly formatted for z/OSMF
@@ -69,7 +197,10 @@ func (jm *ZOSMFJobManager) SubmitJobFromDataset(dataset string, volume string) (
 	// z/OSMF automatically adds the user prefix, so we should use relative dataset names
 	// If the dataset name starts with the user ID, remove it to avoid duplication
 	// This is a common pattern in z/OSMF APIs
-	session := jm.session.(*profile.Session)
+	session, err := jm.getSession()
+	if err != nil {
+		return nil, err
+	}
 	userID := session.User
 	if strings.HasPrefix(dataset, userID+".") {
 		dataset = strings.TrimPrefix(dataset, userID+".")
@@ -92,25 +223,149 @@ func (jm *ZOSMFJobManager) SubmitJobFromLocalFile(localFile, directory, extensio
 	return jm.SubmitJob(request)
 }
 
-// WaitForJobCompletion waits for a job to complete and returns the final status
-func (jm *ZOSMFJobManager) WaitForJobCompletion(correlator string, timeout time.Duration, pollInterval time.Duration) (string, error) {
+// GetSpoolFileText reads a spool file as text converted from the given
+// CCSID, e.g. 1047 or 37. A ccsid of 0 uses the z/OSMF default conversion.
+func (jm *ZOSMFJobManager) GetSpoolFileText(correlator string, spoolID, ccsid int) (string, error) {
+	jobName, jobID, err := parseCorrelator(correlator)
+	if err != nil {
+		return "", fmt.Errorf("invalid correlator format: %w", err)
+	}
+
+	opts := &SpoolContentOptions{Mode: SpoolModeText}
+	if ccsid > 0 {
+		opts.Encoding = fmt.Sprintf("IBM-%03d", ccsid)
+	}
+	return jm.GetSpoolFileContentWithOptions(jobName, jobID, spoolID, opts)
+}
+
+// GetSpoolFileContentRange reads count records of a spool file starting at
+// the zero-based record start. correlator may be "jobname:jobid" or a bare
+// job ID. One extra record is requested to tell whether more remain.
+func (jm *ZOSMFJobManager) GetSpoolFileContentRange(correlator string, spoolID, start, count int) (*SpoolRecords, error) {
+	if start < 0 || count <= 0 {
+		return nil, fmt.Errorf("invalid record range: start %d, count %d", start, count)
+	}
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := jm.GetSpoolFileContentWithOptions(jobName, jobID, spoolID, &SpoolContentOptions{
+		StartRecord: start,
+		RecordCount: count + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := []string{}
+	if content != "" {
+		records = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+	page := &SpoolRecords{StartRecord: start}
+	if len(records) > count {
+		page.More = true
+		records = records[:count]
+	}
+	page.Records = records
+	return page, nil
+}
+
+// WaitForJobCompletion waits for a job to reach OUTPUT and returns its result.
+// A job that ends with a JCL error or abend still completes; check Succeeded.
+func (jm *ZOSMFJobManager) WaitForJobCompletion(correlator string, timeout time.Duration, pollInterval time.Duration) (*JobResult, error) {
+	job, err := jm.waitForJob(correlator, timeout, pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	return newJobResult(job), nil
+}
+
+// WaitForJobCompletionAsync polls a job in the background until it completes.
+// Exactly one WaitResult is delivered on the returned channel, which is then
+// closed. Calling the returned cancel func stops polling and delivers
+// ErrWaitCanceled if the job hadn't completed yet; it is safe to call more
+// than once and after completion.
+func (jm *ZOSMFJobManager) WaitForJobCompletionAsync(correlator string, pollInterval time.Duration) (<-chan WaitResult, func()) {
+	results := make(chan WaitResult, 1)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	cancel := func() {
+		stopOnce.Do(func() { close(stop) })
+	}
+
+	go func() {
+		defer close(results)
+		for {
+			job, err := jm.GetJob(correlator)
+			if err != nil {
+				results <- WaitResult{Err: fmt.Errorf("failed to get job status: %w", err)}
+				return
+			}
+			if isJobComplete(job) {
+				results <- WaitResult{Result: newJobResult(job)}
+				return
+			}
+
+			select {
+			case <-time.After(pollInterval):
+			case <-stop:
+				results <- WaitResult{Err: fmt.Errorf("%w: job %s", ErrWaitCanceled, correlator)}
+				return
+			}
+		}
+	}()
+
+	return results, cancel
+}
+
+// SubmitJobAndWait submits a job and polls until it completes, returning the
+// final job with its retcode. A JCL error, whether reported on submit or at
+// completion, returns ErrJCLError along with whatever job details are known.
+func (jm *ZOSMFJobManager) SubmitJobAndWait(request *SubmitJobRequest, timeout, pollInterval time.Duration) (*Job, error) {
+	submitted, err := jm.SubmitJob(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit job: %w", err)
+	}
+	if submitted.JobName == "" || submitted.JobID == "" {
+		return nil, fmt.Errorf("submit response did not identify the job")
+	}
+
+	correlator := submitted.JobName + ":" + submitted.JobID
+	if isJCLError(submitted.Status) {
+		return &Job{JobID: submitted.JobID, JobName: submitted.JobName, Owner: submitted.Owner, Status: submitted.Status},
+			fmt.Errorf("%w: job %s", ErrJCLError, correlator)
+	}
+
+	job, err := jm.waitForJob(correlator, timeout, pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if isJCLError(job.RetCode) {
+		return job, fmt.Errorf("%w: job %s", ErrJCLError, correlator)
+	}
+	return job, nil
+}
+
+// waitForJob polls a job until it completes or timeout passes
+func (jm *ZOSMFJobManager) waitForJob(correlator string, timeout time.Duration, pollInterval time.Duration) (*Job, error) {
 	startTime := time.Now()
 	
 	for {
 		// Check if timeout exceeded
 		if time.Since(startTime) > timeout {
-			return "", fmt.Errorf("timeout waiting for job %s to complete", correlator)
+			return nil, fmt.Errorf("timeout waiting for job %s to complete", correlator)
 		}
 
 		// Get job status
-		status, err := jm.GetJobStatus(correlator)
+		job, err := jm.GetJob(correlator)
 		if err != nil {
-			return "", fmt.Errorf("failed to get job status: %w", err)
+			return nil, fmt.Errorf("failed to get job status: %w", err)
 		}
 
 		// Check if job is complete
-		if isJobComplete(status) {
-			return status, nil
+		if isJobComplete(job) {
+			return job, nil
 		}
 
 		// Wait before next poll
@@ -118,17 +373,89 @@ func (jm *ZOSMFJobManager) WaitForJobCompletion(correlator string, timeout time.
 	}
 }
 
-// isJobComplete checks if a job status indicates completion
-func isJobComplete(status string) bool {
-	completedStatuses := []string{"OUTPUT", "CC 0000", "CC 0001", "CC 0002", "CC 0003", "CC 0004", "ABEND"}
-	status = strings.ToUpper(status)
-	
-	for _, completedStatus := range completedStatuses {
-		if strings.Contains(status, completedStatus) {
-			return true
+// isJCLError reports whether a status or retcode is a JCL error
+func isJCLError(value string) bool {
+	return strings.Contains(strings.ToUpper(value), "JCL ERROR")
+}
+
+// isJobComplete checks if a job has finished and its output is available
+func isJobComplete(job *Job) bool {
+	return strings.EqualFold(job.Status, "OUTPUT")
+}
+
+// newJobResult builds a result from the retcode of a finished job
+func newJobResult(job *Job) *JobResult {
+	result := &JobResult{
+		JobID:         job.JobID,
+		JobName:       job.JobName,
+		Status:        job.Status,
+		RetCode:       job.RetCode,
+		ConditionCode: -1,
+	}
+
+	// Only "CC nnnn" carries a condition code; JCL ERROR, ABEND Sxxx/Uxxxx,
+	// SEC ERROR, CANCELED and the like are all failures
+	retCode := strings.ToUpper(strings.TrimSpace(job.RetCode))
+	if strings.HasPrefix(retCode, "CC ") {
+		if cc, err := strconv.Atoi(strings.TrimSpace(retCode[3:])); err == nil {
+			result.ConditionCode = cc
+			result.Succeeded = cc == 0
 		}
 	}
-	return false
+	return result
+}
+
+// CanPurge reports whether a job is in a state where purging makes sense.
+// A missing job is not an error; it returns false with a reason instead.
+func (jm *ZOSMFJobManager) CanPurge(correlator string) (bool, string, error) {
+	job, err := jm.GetJob(correlator)
+	if err != nil {
+		if errors.Is(err, ErrJobNotFound) {
+			return false, "job not found or already purged", nil
+		}
+		return false, "", fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if strings.EqualFold(job.Status, "ACTIVE") {
+		return false, "job is still active", nil
+	}
+	return true, "", nil
+}
+
+// CancelAndPurge cancels a job given by its JES job ID and then purges it,
+// addressing it by name and ID once GetJobByID has found it. A cancel
+// rejected because the job is no longer active is ignored; the purge is
+// attempted either way. An error comes back when the job can't be found or
+// the purge fails, joined with any other cancel failure.
+func (jm *ZOSMFJobManager) CancelAndPurge(jobID string) error {
+	job, err := jm.GetJobByID(jobID)
+	if err != nil {
+		return err
+	}
+	correlator := job.JobName + ":" + job.JobID
+
+	cancelErr := jm.CancelJob(correlator)
+	if isNotActiveRejection(cancelErr) {
+		cancelErr = nil
+	}
+
+	if err := jm.PurgeJob(correlator); err != nil {
+		if cancelErr != nil {
+			cancelErr = fmt.Errorf("failed to cancel job: %w", cancelErr)
+		}
+		return errors.Join(cancelErr, fmt.Errorf("failed to purge job: %w", err))
+	}
+	return nil
+}
+
+// isNotActiveRejection reports whether a cancel was refused only because the
+// job had already stopped running
+func isNotActiveRejection(err error) bool {
+	var apiErr *profile.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode == http.StatusNotFound {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 && reportsJobNotActive(apiErr.Body)
 }
 
 // GetJobsByOwner retrieves jobs owned by a specific user
@@ -158,104 +485,167 @@ func (jm *ZOSMFJobManager) GetJobsByStatus(status string, maxJobs int) (*JobList
 	return jm.ListJobs(filter)
 }
 
-// GetJobOutput retrieves the output of a completed job
+// GetJobOutput retrieves the output of a completed job keyed by DD name.
+// Spool files that can't be fetched are left out of the map and their
+// errors returned joined, alongside everything that could be read.
 func (jm *ZOSMFJobManager) GetJobOutput(correlator string) (map[string]string, error) {
-	var jobName, jobID string
-	var err error
-	
-	// Check if it's already in correlator format (jobname:jobid)
-	if strings.Contains(correlator, ":") {
-		// Parse correlator to get jobname and jobid
-		jobName, jobID, err = parseCorrelator(correlator)
-		if err != nil {
-			return nil, fmt.Errorf("invalid correlator format: %w", err)
-		}
-	} else {
-		// If it's just a job ID, we need to find the job first
-		jobFilter := &JobFilter{
-			JobID: correlator,
-			MaxJobs: 100,
-		}
-		
-		jobList, err := jm.ListJobs(jobFilter)
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get spool files
+	spoolFiles, err := jm.GetSpoolFiles(jobName, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spool files: %w", err)
+	}
+
+	// Get content for each spool file, carrying on past failures
+	output := make(map[string]string)
+	var errs []error
+	for _, spoolFile := range spoolFiles {
+		content, err := jm.GetSpoolFileContent(jobName, jobID, spoolFile.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to find job with ID %s: %w", correlator, err)
+			errs = append(errs, fmt.Errorf("spool file %d (%s): %w", spoolFile.ID, spoolFile.DDName, err))
+			continue
 		}
-		
-		// Find the job with the specified job ID
-		found := false
-		for _, job := range jobList.Jobs {
-			if job.JobID == correlator {
-				jobName = job.JobName
-				jobID = job.JobID
-				found = true
-				break
+		output[spoolFile.DDName] = content
+	}
+
+	return output, errors.Join(errs...)
+}
+
+// GetJobOutputConcurrent fetches every spool file of a job with up to
+// concurrency requests in flight. Sections come back in spool file order;
+// a failed file keeps its place with Err set, and all failures are also
+// returned joined together.
+func (jm *ZOSMFJobManager) GetJobOutputConcurrent(correlator string, concurrency int) ([]SpoolSection, error) {
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return nil, err
+	}
+
+	spoolFiles, err := jm.GetSpoolFiles(jobName, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spool files: %w", err)
+	}
+
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	sections := make([]SpoolSection, len(spoolFiles))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				sections[i].SpoolFile = spoolFiles[i]
+				content, err := jm.GetSpoolFileContent(jobName, jobID, spoolFiles[i].ID)
+				if err != nil {
+					sections[i].Err = fmt.Errorf("spool file %d (%s): %w", spoolFiles[i].ID, spoolFiles[i].DDName, err)
+					continue
+				}
+				sections[i].Content = content
 			}
-		}
-		
-		if !found {
-			return nil, fmt.Errorf("job with ID %s not found", correlator)
+		}()
+	}
+	for i := range spoolFiles {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var errs []error
+	for _, section := range sections {
+		if section.Err != nil {
+			errs = append(errs, section.Err)
 		}
 	}
+	return sections, errors.Join(errs...)
+}
+
+// DownloadJobOutput writes every spool file of a job, JES files included,
+// to targetDir/<stepname>.<ddname>.txt and returns the paths written in
+// spool file order. targetDir is created if needed. When a step and DD
+// name repeat, later files get the spool ID added to stay unique. Files
+// that can't be fetched are skipped and their errors returned joined.
+func (jm *ZOSMFJobManager) DownloadJobOutput(correlator, targetDir string) ([]string, error) {
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return nil, err
+	}
 
-	// Get spool files
 	spoolFiles, err := jm.GetSpoolFiles(jobName, jobID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get spool files: %w", err)
 	}
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create target directory: %w", err)
+	}
 
-	// Get content for each spool file
-	output := make(map[string]string)
+	var paths []string
+	var errs []error
+	used := make(map[string]bool)
 	for _, spoolFile := range spoolFiles {
+		name := spoolFileName(spoolFile)
+		if used[name] {
+			name = fmt.Sprintf("%s.%d", name, spoolFile.ID)
+		}
+		used[name] = true
+
 		content, err := jm.GetSpoolFileContent(jobName, jobID, spoolFile.ID)
 		if err != nil {
-			// Log error but continue with other files
+			errs = append(errs, fmt.Errorf("spool file %d (%s): %w", spoolFile.ID, spoolFile.DDName, err))
 			continue
 		}
-		output[spoolFile.DDName] = content
+		path := filepath.Join(targetDir, name+".txt")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			errs = append(errs, fmt.Errorf("spool file %d (%s): %w", spoolFile.ID, spoolFile.DDName, err))
+			continue
+		}
+		paths = append(paths, path)
 	}
+	return paths, errors.Join(errs...)
+}
 
-	return output, nil
+// spoolFileName is the <stepname>.<ddname> base name DownloadJobOutput uses
+func spoolFileName(spoolFile SpoolFile) string {
+	stepName := spoolFile.StepName
+	if stepName == "" {
+		stepName = "JES2"
+	}
+	return stepName + "." + spoolFile.DDName
 }
 
-// GetJobOutputByDDName retrieves the output of a specific DD name for a job
-func (jm *ZOSMFJobManager) GetJobOutputByDDName(correlator, ddName string) (string, error) {
-	var jobName, jobID string
-	var err error
-	
+// resolveJob turns a "jobname:jobid" correlator or a bare job ID into the
+// job's name and ID, looking bare IDs up with GetJobByID
+func (jm *ZOSMFJobManager) resolveJob(correlator string) (jobName, jobID string, err error) {
 	// Check if it's already in correlator format (jobname:jobid)
 	if strings.Contains(correlator, ":") {
 		// Parse correlator to get jobname and jobid
 		jobName, jobID, err = parseCorrelator(correlator)
 		if err != nil {
-			return "", fmt.Errorf("invalid correlator format: %w", err)
-		}
-	} else {
-		// If it's just a job ID, we need to find the job first
-		jobFilter := &JobFilter{
-			JobID: correlator,
-			MaxJobs: 100,
-		}
-		
-		jobList, err := jm.ListJobs(jobFilter)
-		if err != nil {
-			return "", fmt.Errorf("failed to find job with ID %s: %w", correlator, err)
-		}
-		
-		// Find the job with the specified job ID
-		found := false
-		for _, job := range jobList.Jobs {
-			if job.JobID == correlator {
-				jobName = job.JobName
-				jobID = job.JobID
-				found = true
-				break
-			}
-		}
-		
-		if !found {
-			return "", fmt.Errorf("job with ID %s not found", correlator)
+			return "", "", fmt.Errorf("invalid correlator format: %w", err)
 		}
+		return jobName, jobID, nil
+	}
+
+	// If it's just a job ID, we need to find the job first
+	job, err := jm.GetJobByID(correlator)
+	if err != nil {
+		return "", "", err
+	}
+	return job.JobName, job.JobID, nil
+}
+
+// GetJobOutputByDDName retrieves the output of a specific DD name for a job
+func (jm *ZOSMFJobManager) GetJobOutputByDDName(correlator, ddName string) (string, error) {
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return "", err
 	}
 
 	// Get spool files
@@ -278,6 +668,153 @@ func (jm *ZOSMFJobManager) GetJobOutputByDDName(correlator, ddName string) (stri
 	return "", fmt.Errorf("DD name %s not found for job %s", ddName, correlator)
 }
 
+// GetSpoolContentByStepDD retrieves the output of the DD in the given step,
+// for jobs where the same DD name (e.g. SYSPRINT) appears in several steps.
+// stepName may be "STEP.PROCSTEP" to pick a step inside a cataloged procedure.
+func (jm *ZOSMFJobManager) GetSpoolContentByStepDD(correlator, stepName, ddName string) (string, error) {
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return "", err
+	}
+
+	spoolFiles, err := jm.GetSpoolFiles(jobName, jobID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get spool files: %w", err)
+	}
+
+	for _, spoolFile := range spoolFiles {
+		if !matchesStepDD(spoolFile, stepName, ddName) {
+			continue
+		}
+		content, err := jm.GetSpoolFileContent(jobName, jobID, spoolFile.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get content for DD %s.%s: %w", stepName, ddName, err)
+		}
+		return content, nil
+	}
+
+	return "", fmt.Errorf("DD name %s.%s not found for job %s", stepName, ddName, correlator)
+}
+
+// matchesStepDD reports whether a spool file is ddName in stepName, which
+// may be "STEP.PROCSTEP"; an empty stepName matches the DD in any step
+func matchesStepDD(spoolFile SpoolFile, stepName, ddName string) bool {
+	if spoolFile.DDName != ddName {
+		return false
+	}
+	if stepName == "" {
+		return true
+	}
+	step, procStep, hasProcStep := strings.Cut(stepName, ".")
+	if spoolFile.StepName != step {
+		return false
+	}
+	return !hasProcStep || spoolFile.ProcStep == procStep
+}
+
+// tailChunkRecords is how many spool records TailJobOutput asks for at once
+const tailChunkRecords = 5000
+
+// TailJobOutput follows a job's spool file for ddName while the job runs,
+// writing each record to out (newline-terminated) as it is appended. It
+// polls every pollInterval, reading only records past the last one written,
+// and returns once the job has completed and its output has been drained.
+// The first DD of that name is followed; use TailJobOutputByStepDD when
+// several steps write it. Canceling ctx stops it with ctx.Err(), including
+// mid-request.
+func (jm *ZOSMFJobManager) TailJobOutput(ctx context.Context, correlator, ddName string, out io.Writer, pollInterval time.Duration) error {
+	return jm.TailJobOutputByStepDD(ctx, correlator, "", ddName, out, pollInterval)
+}
+
+// TailJobOutputByStepDD is TailJobOutput for the ddName of one step.
+// stepName may be "STEP.PROCSTEP"; an empty stepName follows the first DD
+// of that name in any step.
+func (jm *ZOSMFJobManager) TailJobOutputByStepDD(ctx context.Context, correlator, stepName, ddName string, out io.Writer, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	jobName, jobID, err := jm.resolveJob(correlator)
+	if err != nil {
+		return err
+	}
+
+	spoolID := 0
+	offset := 0
+	for {
+		// Check status before reading, so a completed job's final read
+		// sees everything it wrote
+		job, err := jm.getJobByNameID(ctx, jobName, jobID)
+		if err != nil {
+			return fmt.Errorf("failed to get job status: %w", err)
+		}
+		done := isJobComplete(job)
+
+		if spoolID == 0 {
+			// The DD only shows up once the step writing it has started
+			spoolFiles, err := jm.getSpoolFiles(ctx, jobName, jobID)
+			if err != nil {
+				return fmt.Errorf("failed to get spool files: %w", err)
+			}
+			for _, spoolFile := range spoolFiles {
+				if matchesStepDD(spoolFile, stepName, ddName) {
+					spoolID = spoolFile.ID
+					break
+				}
+			}
+		}
+
+		if spoolID != 0 {
+			offset, err = jm.tailSpoolFile(ctx, jobName, jobID, spoolID, offset, out)
+			if err != nil {
+				return err
+			}
+		}
+
+		if done {
+			if spoolID == 0 {
+				if stepName != "" {
+					return fmt.Errorf("DD name %s.%s not found for job %s", stepName, ddName, correlator)
+				}
+				return fmt.Errorf("DD name %s not found for job %s", ddName, correlator)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// tailSpoolFile writes the records of a spool file from offset on to out and
+// returns the offset after the last record written
+func (jm *ZOSMFJobManager) tailSpoolFile(ctx context.Context, jobName, jobID string, spoolID, offset int, out io.Writer) (int, error) {
+	for {
+		content, err := jm.getSpoolFileContent(ctx, jobName, jobID, spoolID, &SpoolContentOptions{
+			StartRecord: offset,
+			RecordCount: tailChunkRecords,
+		})
+		if err != nil {
+			return offset, fmt.Errorf("failed to read spool file %d: %w", spoolID, err)
+		}
+		if content == "" {
+			return offset, nil
+		}
+
+		content = strings.TrimSuffix(content, "\n")
+		records := strings.Count(content, "\n") + 1
+		if _, err := io.WriteString(out, content+"\n"); err != nil {
+			return offset, fmt.Errorf("failed to write output: %w", err)
+		}
+		offset += records
+		if records < tailChunkRecords {
+			return offset, nil
+		}
+	}
+}
+
 // ValidateJobRequest validates a job submission request
 func ValidateJobRequest(request *SubmitJobRequest) error {
 	if request == nil {
@@ -303,6 +840,41 @@ func ValidateJobRequest(request *SubmitJobRequest) error {
 		}
 	}
 
+	// Validate JCL symbols
+	if err := validateJCLSymbols(request.Symbols); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateJCLSymbolName validates a JCL symbol name: 1-8 characters,
+// starting with a letter or national character (@, #, $)
+func ValidateJCLSymbolName(name string) error {
+	if len(name) == 0 || len(name) > 8 {
+		return fmt.Errorf("JCL symbol name %q must be 1-8 characters", name)
+	}
+
+	name = strings.ToUpper(name)
+	first := rune(name[0])
+	if !((first >= 'A' && first <= 'Z') || first == '@' || first == '#' || first == '$') {
+		return fmt.Errorf("JCL symbol name %q must start with a letter or @, #, $", name)
+	}
+	for _, char := range name {
+		if !((char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') || char == '@' || char == '#' || char == '$') {
+			return fmt.Errorf("JCL symbol name %q contains invalid character %q", name, char)
+		}
+	}
+	return nil
+}
+
+// validateJCLSymbols checks every symbol name in a submit request
+func validateJCLSymbols(symbols map[string]string) error {
+	for name := range symbols {
+		if err := ValidateJCLSymbolName(name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -377,3 +949,93 @@ func CreateJobWithStep(jobName, account, user, msgClass, msgLevel, stepName, pgm
 	
 	return jcl
 }
+
+// jclMaxColumn is the last column JCL statements may use
+const jclMaxColumn = 71
+
+// jclContinuation starts a continued operand in column 16
+const jclContinuation = "//             "
+
+// FormatDDStatement renders a DD statement, including any concatenations
+// and in-stream data, as JCL lines joined by newlines
+func FormatDDStatement(dd DDStatement) string {
+	var lines []string
+	lines = append(lines, formatDDLines(dd)...)
+	for _, concat := range dd.Concatenations {
+		// Concatenated DDs never carry a name of their own
+		concat.Name = ""
+		concat.Concatenations = nil
+		lines = append(lines, formatDDLines(concat)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatDDLines renders a single DD statement without concatenations
+func formatDDLines(dd DDStatement) []string {
+	var operands []string
+	switch {
+	case len(dd.InStream) > 0:
+		operands = append(operands, "*")
+	case dd.Dummy:
+		operands = append(operands, "DUMMY")
+	case dd.Sysout != "":
+		operands = append(operands, "SYSOUT="+dd.Sysout)
+	}
+	if dd.DSN != "" {
+		operands = append(operands, "DSN="+dd.DSN)
+	}
+	if dd.Disp != "" {
+		operands = append(operands, "DISP="+parenthesize(dd.Disp))
+	}
+	if dd.Unit != "" {
+		operands = append(operands, "UNIT="+dd.Unit)
+	}
+	if dd.Space != "" {
+		operands = append(operands, "SPACE="+parenthesize(dd.Space))
+	}
+	if dd.DCB != "" {
+		operands = append(operands, "DCB="+parenthesize(dd.DCB))
+	}
+
+	lines := wrapJCLOperands(fmt.Sprintf("//%s DD ", dd.Name), operands)
+	if len(dd.InStream) > 0 {
+		lines = append(lines, dd.InStream...)
+		lines = append(lines, "/*")
+	}
+	return lines
+}
+
+// wrapJCLOperands joins operands after prefix, continuing onto new
+// lines when a statement would run past column 71
+func wrapJCLOperands(prefix string, operands []string) []string {
+	var lines []string
+	line := prefix
+	for i, operand := range operands {
+		if i < len(operands)-1 {
+			operand += ","
+		}
+		if i > 0 && len(line)+len(operand) > jclMaxColumn {
+			lines = append(lines, line)
+			line = jclContinuation
+		}
+		line += operand
+	}
+	return append(lines, strings.TrimRight(line, " "))
+}
+
+// parenthesize wraps a multi-value operand in parentheses if it isn't already
+func parenthesize(value string) string {
+	if strings.Contains(value, ",") && !(strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")) {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+// CreateJobWithDDs creates a complete JCL job with a step built from typed DD statements
+func CreateJobWithDDs(jobName, account, user, msgClass, msgLevel, stepName, pgm string, dds []DDStatement) string {
+	ddStatements := make([]string, 0, len(dds))
+	for _, dd := range dds {
+		ddStatements = append(ddStatements, FormatDDStatement(dd))
+	}
+	return CreateJobWithStep(jobName, account, user, msgClass, msgLevel, stepName, pgm, ddStatements)
+}
@@ -1,11 +1,19 @@
 package jobs
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -71,6 +79,19 @@ func TestNewJobManagerFromProfile(t *testing.T) {
 	assert.NotNil(t, jm)
 }
 
+func TestNewJobManagerNilSession(t *testing.T) {
+	// A manager built without a session reports an error instead of panicking
+	jm := NewJobManager(nil)
+
+	_, err := jm.ListJobs(nil)
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	_, err = jm.SubmitJobText("//TEST JOB")
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	assert.NoError(t, jm.CloseJobManager())
+}
+
 func TestCreateJobManager(t *testing.T) {
 	// Create a test profile manager
 	pm := &profile.ZOSMFProfileManager{}
@@ -152,6 +173,189 @@ func TestListJobs(t *testing.T) {
 	assert.Equal(t, "OUTPUT", jobList.Jobs[0].Status)
 }
 
+// Run with -race: requests read the session headers while another
+// goroutine changes them
+func TestListJobsConcurrentHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"jobid":"JOB001","jobname":"A"}]`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	stop := make(chan struct{})
+	toggled := make(chan struct{})
+	go func() {
+		defer close(toggled)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				session.AddHeader("X-Trace", strconv.Itoa(i))
+			} else {
+				session.RemoveHeader("X-Trace")
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				list, err := jm.ListJobs(&JobFilter{Owner: "TESTUSER"})
+				assert.NoError(t, err)
+				if err == nil {
+					assert.Len(t, list.Jobs, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-toggled
+}
+
+func TestListJobsTruncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"jobid":"JOB001","jobname":"A"},{"jobid":"JOB002","jobname":"B"}]`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// Filled the requested max-jobs, so there may be more
+	list, err := jm.ListJobs(&JobFilter{Owner: "TESTUSER", MaxJobs: 2})
+	require.NoError(t, err)
+	assert.True(t, list.Truncated)
+
+	list, err = jm.ListJobs(&JobFilter{Owner: "TESTUSER", MaxJobs: 10})
+	require.NoError(t, err)
+	assert.False(t, list.Truncated)
+
+	list, err = jm.ListJobs(nil)
+	require.NoError(t, err)
+	assert.False(t, list.Truncated)
+}
+
+func TestListJobsSubmittedWindow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Y", r.URL.Query().Get("exec-data"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"jobid":"JOB001","jobname":"OLD","exec-submitted":"2026-10-15T08:00:00.000Z"},
+			{"jobid":"JOB002","jobname":"RECENT","exec-submitted":"2026-10-16T09:30:00.000Z","exec-started":"2026-10-16T09:30:01.000Z"},
+			{"jobid":"JOB003","jobname":"LATEST","exec-submitted":"2026-10-16T11:59:00.000Z"},
+			{"jobid":"JOB004","jobname":"QUEUED"}
+		]`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	names := func(list *JobList) []string {
+		var result []string
+		for _, job := range list.Jobs {
+			result = append(result, job.JobName)
+		}
+		return result
+	}
+
+	// Last few hours
+	list, err := jm.ListJobs(&JobFilter{SubmittedAfter: time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RECENT", "LATEST"}, names(list))
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 30, 1, 0, time.UTC), list.Jobs[0].Started)
+
+	// Both bounds; the upper one is exclusive
+	list, err = jm.ListJobs(&JobFilter{
+		SubmittedAfter:  time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		SubmittedBefore: time.Date(2026, 10, 16, 11, 59, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OLD", "RECENT"}, names(list))
+
+	// Pages are filtered too
+	var paged []string
+	err = jm.ListJobsPages(&JobFilter{SubmittedBefore: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}, 10, func(page []Job) bool {
+		for _, job := range page {
+			paged = append(paged, job.JobName)
+		}
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OLD"}, paged)
+}
+
+func TestParseExecTime(t *testing.T) {
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC), parseExecTime("2026-10-16T09:30:00.000Z"))
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC), parseExecTime("2026-10-16T09:30:00"))
+	assert.True(t, parseExecTime("").IsZero())
+	assert.True(t, parseExecTime("yesterday").IsZero())
+}
+
+func TestGetJobFullPayload(t *testing.T) {
+	// Everything z/OSMF returns for a job listed with exec-data
+	payload := `{
+		"jobid":"JOB01234","jobname":"PAYROLL","subsystem":"JES2","owner":"IBMUSER",
+		"status":"OUTPUT","type":"JOB","class":"A","retcode":"CC 0004",
+		"url":"https://host/zosmf/restjobs/jobs/J0001234SY1.....C1234567.......%3A",
+		"files-url":"https://host/zosmf/restjobs/jobs/J0001234SY1.....C1234567.......%3A/files",
+		"job-correlator":"J0001234SY1.....C1234567.......:",
+		"phase":20,"phase-name":"Job is on the hard copy queue","reason-not-running":"",
+		"exec-system":"SY1","exec-member":"SY1",
+		"exec-submitted":"2026-10-16T09:30:00.000Z","exec-started":"2026-10-16T09:30:01.000Z","exec-ended":"2026-10-16T09:31:12.000Z"
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/restjobs/jobs" {
+			w.Write([]byte("[" + payload + "]"))
+			return
+		}
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+	jm.StrictJSON = true
+
+	job, err := jm.GetJob("PAYROLL:JOB01234")
+	require.NoError(t, err)
+	assert.Equal(t, 20, job.Phase)
+	assert.Equal(t, "Job is on the hard copy queue", job.PhaseName)
+	assert.Equal(t, "A", job.Class)
+	assert.Equal(t, "CC 0004", job.RetCode)
+	assert.Equal(t, "J0001234SY1.....C1234567.......:", job.JobCorrelator)
+	assert.Equal(t, "2026-10-16T09:30:01.000Z", job.ExecStarted)
+	assert.Equal(t, "2026-10-16T09:31:12.000Z", job.ExecEnded)
+
+	list, err := jm.ListJobs(&JobFilter{ExecData: true})
+	require.NoError(t, err)
+	require.Len(t, list.Jobs, 1)
+	assert.Equal(t, 20, list.Jobs[0].Phase)
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 31, 12, 0, time.UTC), list.Jobs[0].Ended)
+
+	// Older responses with only the basics still decode
+	var minimal Job
+	require.NoError(t, json.Unmarshal([]byte(`{"jobid":"JOB001","jobname":"A","owner":"U","status":"INPUT"}`), &minimal))
+	assert.Zero(t, minimal.Phase)
+	assert.Empty(t, minimal.ExecStarted)
+}
+
 func TestGetJob(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -190,6 +394,99 @@ func TestGetJob(t *testing.T) {
 	assert.Equal(t, "CC 0000", job.RetCode)
 }
 
+func TestGetJobIdentifierForms(t *testing.T) {
+	const correlator = "J0001234SY1.....C1234567.......:"
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/restjobs/jobs":
+			assert.Equal(t, "JOB01234", r.URL.Query().Get("jobid"))
+			json.NewEncoder(w).Encode([]Job{{JobID: "JOB01234", JobName: "PAYROLL"}})
+		case "/api/v1/restjobs/jobs/PAYROLL/JOB01234":
+			json.NewEncoder(w).Encode(Job{JobID: "JOB01234", JobName: "PAYROLL", Status: "OUTPUT"})
+		case "/api/v1/restjobs/jobs/J0001234SY1.....C1234567.......:":
+			json.NewEncoder(w).Encode(Job{JobID: "J0001234", JobName: "NIGHTLY", Status: "ACTIVE"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// A bare job ID is resolved to its name first
+	job, err := jm.GetJob("JOB01234")
+	require.NoError(t, err)
+	assert.Equal(t, "PAYROLL", job.JobName)
+	assert.Equal(t, []string{"/api/v1/restjobs/jobs", "/api/v1/restjobs/jobs/PAYROLL/JOB01234"}, paths)
+
+	// Anything else goes to the correlator endpoint as is
+	paths = nil
+	job, err = jm.GetJob(correlator)
+	require.NoError(t, err)
+	assert.Equal(t, "NIGHTLY", job.JobName)
+	assert.Equal(t, []string{"/api/v1/restjobs/jobs/" + correlator}, paths)
+}
+
+func TestIsJobID(t *testing.T) {
+	for _, id := range []string{"JOB01234", "STC00001", "TSU99999", "J0123456", "S1234567", "job01234"} {
+		assert.True(t, isJobID(id), id)
+	}
+	for _, id := range []string{"JOB1234", "JOB012345", "J012345", "X0123456", "J0001234SY1.....C1234567.......:", ""} {
+		assert.False(t, isJobID(id), id)
+	}
+}
+
+func TestGetJobStrictJSON(t *testing.T) {
+	// A field the SDK doesn't model yet
+	payload := `{"jobid":"JOB001","jobname":"TESTJOB1","owner":"testuser","status":"OUTPUT","newfield":1}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// Lenient by default
+	job, err := jm.GetJob("TESTJOB1:JOB001")
+	require.NoError(t, err)
+	assert.Equal(t, "JOB001", job.JobID)
+
+	// Strict mode reports the unknown field
+	jm.StrictJSON = true
+	_, err = jm.GetJob("TESTJOB1:JOB001")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "newfield")
+}
+
+func TestGetJobResponseTooLarge(t *testing.T) {
+	payload := `{"jobid":"JOB001","jobname":"TESTJOB1","owner":"testuser","status":"OUTPUT"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	session.MaxResponseBytes = 16
+	jm := NewJobManager(session)
+
+	_, err = jm.GetJob("TESTJOB1:JOB001")
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, profile.ErrResponseTooLarge)
+}
+
 func TestGetJobStatus(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -269,6 +566,41 @@ func TestSubmitJob(t *testing.T) {
 	assert.Equal(t, "ACTIVE", response.Status)
 }
 
+func TestSubmitJobFeedbackHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Sparse body without the jobid; identity is in the headers
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "https://host:443/zosmf/restjobs/jobs/TESTJOB/JOB00123")
+		w.Header().Set("X-IBM-Job-Correlator", "J0000123SY1.....D5A2B4C1.......:")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"jobname":"STALE","owner":"testuser","status":"INPUT"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	response, err := jm.SubmitJob(&SubmitJobRequest{JobStatement: "//TESTJOB JOB (ACCT)"})
+	require.NoError(t, err)
+	assert.Equal(t, "JOB00123", response.JobID)
+	assert.Equal(t, "TESTJOB", response.JobName)
+	assert.Equal(t, "testuser", response.Owner)
+	assert.Equal(t, "J0000123SY1.....D5A2B4C1.......:", response.JobCorrelator)
+	assert.Equal(t, "https://host:443/zosmf/restjobs/jobs/TESTJOB/JOB00123", response.URL)
+
+	// Explicit job ID header wins over the Location path
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://host:443/zosmf/restjobs/jobs/TESTJOB/JOB00123")
+		w.Header().Set("X-IBM-Job-Id", "JOB00999")
+		w.WriteHeader(http.StatusCreated)
+	})
+	response, err = jm.SubmitJob(&SubmitJobRequest{JobStatement: "//TESTJOB JOB (ACCT)"})
+	require.NoError(t, err)
+	assert.Equal(t, "JOB00999", response.JobID)
+}
+
 func TestSubmitJobStatement(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -303,6 +635,92 @@ func TestSubmitJobStatement(t *testing.T) {
 	assert.Equal(t, "JOB001", response.JobID)
 }
 
+func TestSubmitJobStatementWithSymbols(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"jobid":"JOB001","jobname":"TESTJOB","owner":"testuser","status":"INPUT"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	jcl := "//TESTJOB JOB (ACCT)\n//STEP1 EXEC PGM=&PGM\n//IN DD DSN=&HLQ..DATA,DISP=SHR"
+	response, err := jm.SubmitJobStatementWithSymbols(jcl, map[string]string{
+		"PGM": "IEBGENER",
+		"hlq": "TESTUSER",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "JOB001", response.JobID)
+	assert.Equal(t, "IEBGENER", headers.Get("X-IBM-JCL-Symbol-PGM"))
+	assert.Equal(t, "TESTUSER", headers.Get("X-IBM-JCL-Symbol-HLQ"))
+
+	// Invalid names are rejected before anything is sent
+	headers = nil
+	for _, name := range []string{"", "TOOLONGNAME", "1ST", "BAD-NAME"} {
+		_, err = jm.SubmitJobStatementWithSymbols(jcl, map[string]string{name: "X"})
+		assert.Error(t, err, name)
+	}
+	assert.Nil(t, headers)
+}
+
+func TestValidateJCLSymbolName(t *testing.T) {
+	for _, name := range []string{"A", "HLQ", "$SYS", "#1", "@ABCDEFG"} {
+		assert.NoError(t, ValidateJCLSymbolName(name), name)
+	}
+	for _, name := range []string{"", "ABCDEFGHI", "9LIVES", "A.B", "A B"} {
+		assert.Error(t, ValidateJCLSymbolName(name), name)
+	}
+}
+
+func TestSubmitJobText(t *testing.T) {
+	var headers http.Header
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restjobs/jobs", r.URL.Path)
+		headers = r.Header.Clone()
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"jobid":"JOB002","jobname":"MEMJOB","owner":"testuser","status":"INPUT"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	jcl := "//MEMJOB JOB (ACCT)\n//STEP1 EXEC PGM=IEFBR14\n"
+	response, err := jm.SubmitJobText(jcl)
+	require.NoError(t, err)
+	assert.Equal(t, "JOB002", response.JobID)
+	assert.Equal(t, jcl, body)
+	assert.Equal(t, "text/plain", headers.Get("Content-Type"))
+	assert.Equal(t, "A", headers.Get("X-IBM-Intrdr-Class"))
+	assert.Equal(t, "F", headers.Get("X-IBM-Intrdr-Recfm"))
+	assert.Equal(t, "80", headers.Get("X-IBM-Intrdr-Lrecl"))
+	assert.Equal(t, "TEXT", headers.Get("X-IBM-Intrdr-Mode"))
+
+	// Bytes and explicit reader options
+	_, err = jm.SubmitJobBytes([]byte(jcl))
+	require.NoError(t, err)
+	assert.Equal(t, jcl, body)
+
+	_, err = jm.SubmitJCL(strings.NewReader(jcl), &IntrdrOptions{Class: "B", RecFm: "V", LRecl: 255})
+	require.NoError(t, err)
+	assert.Equal(t, "B", headers.Get("X-IBM-Intrdr-Class"))
+	assert.Equal(t, "V", headers.Get("X-IBM-Intrdr-Recfm"))
+	assert.Equal(t, "255", headers.Get("X-IBM-Intrdr-Lrecl"))
+}
+
 func TestSubmitJobFromDataset(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -360,6 +778,129 @@ func TestCancelJob(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCancelAndPurge(t *testing.T) {
+	var cancelStatus, purgeStatus int
+	var cancelBody string
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/info":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/restjobs/jobs":
+			// GetJobByID learns the job name from the listing
+			if r.URL.Query().Get("jobid") != "JOB001" {
+				w.Write([]byte(`[]`))
+				return
+			}
+			w.Write([]byte(`[{"jobname":"TESTJOB","jobid":"JOB001"}]`))
+		case r.Method == "GET" && r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB001":
+			w.Write([]byte(`{"jobname":"TESTJOB","jobid":"JOB001","status":"ACTIVE"}`))
+		case r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB001/cancel":
+			calls = append(calls, r.URL.Path)
+			w.WriteHeader(cancelStatus)
+			w.Write([]byte(cancelBody))
+		case r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB001/purge":
+			calls = append(calls, r.URL.Path)
+			w.WriteHeader(purgeStatus)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+	both := []string{"/api/v1/restjobs/jobs/TESTJOB/JOB001/cancel", "/api/v1/restjobs/jobs/TESTJOB/JOB001/purge"}
+
+	// Still running: cancel then purge, both by name and ID
+	cancelStatus, purgeStatus = http.StatusNoContent, http.StatusNoContent
+	require.NoError(t, jm.CancelAndPurge("JOB001"))
+	assert.Equal(t, both, calls)
+
+	// Already completed: the cancel is rejected but the purge goes ahead
+	calls = nil
+	cancelStatus = http.StatusBadRequest
+	cancelBody = `{"category":6,"rc":4,"reason":10,"message":"Job is not active"}`
+	require.NoError(t, jm.CancelAndPurge("JOB001"))
+	assert.Equal(t, both, calls)
+
+	// A failed purge is reported, along with an unexpected cancel failure
+	cancelStatus, purgeStatus = http.StatusInternalServerError, http.StatusInternalServerError
+	cancelBody = ""
+	err = jm.CancelAndPurge("JOB001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to purge job")
+	assert.Contains(t, err.Error(), "failed to cancel job")
+
+	// A 404 or other 4xx cancel isn't taken for a finished job
+	for _, status := range []int{http.StatusNotFound, http.StatusBadRequest} {
+		cancelStatus = status
+		cancelBody = `{"category":6,"rc":4,"reason":7,"message":"No match for method PUT"}`
+		err = jm.CancelAndPurge("JOB001")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to cancel job")
+	}
+
+	// An ignorable cancel rejection stays out of the purge error
+	cancelStatus = http.StatusBadRequest
+	cancelBody = `{"category":6,"rc":4,"reason":10,"message":"Job is not active"}`
+	err = jm.CancelAndPurge("JOB001")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "failed to cancel job")
+
+	// An unknown job ID is reported before anything is sent
+	calls = nil
+	err = jm.CancelAndPurge("JOB999")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	assert.Empty(t, calls)
+}
+
+func TestHoldAndReleaseJob(t *testing.T) {
+	var method, path, contentType string
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		body = nil
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jobid":"JOB001","jobname":"TESTJOB1","original-jobid":"JOB001","owner":"TESTUSER","member":"JES2","sysname":"SY1","job-correlator":"J0000001SY1.....D5A2B4C1.......:","status":"0"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	tests := []struct {
+		name       string
+		call       func(string) error
+		correlator string
+		wantPath   string
+		wantBody   string
+	}{
+		{"hold by name and id", jm.HoldJob, "TESTJOB1:JOB001", "/api/v1/restjobs/jobs/TESTJOB1/JOB001", "hold"},
+		{"release by name and id", jm.ReleaseJob, "TESTJOB1:JOB001", "/api/v1/restjobs/jobs/TESTJOB1/JOB001", "release"},
+		{"hold by correlator", jm.HoldJob, "J0000001SY1.....D5A2B4C1.......", "/api/v1/restjobs/jobs/J0000001SY1.....D5A2B4C1.......", "hold"},
+		{"release by correlator", jm.ReleaseJob, "J0000001SY1.....D5A2B4C1.......", "/api/v1/restjobs/jobs/J0000001SY1.....D5A2B4C1.......", "release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.call(tt.correlator))
+			assert.Equal(t, "PUT", method)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, "application/json", contentType)
+			assert.Equal(t, map[string]string{"request": tt.wantBody}, body)
+		})
+	}
+}
+
 func TestDeleteJob(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -428,6 +969,49 @@ func TestGetSpoolFiles(t *testing.T) {
 	assert.Equal(t, 2, spoolFiles[1].ID)
 }
 
+func TestGetSpoolFilesDecodesMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"recfm":"UA","records-url":"https://host:443/zosmf/restjobs/jobs/TESTJOB/JOB001/files/2/records",
+			 "stepname":"JES2","subsystem":"JES2","job-correlator":"J0000001SY1.....C1234567.......:",
+			 "byte-count":1198,"lrecl":133,"jobid":"JOB001","ddname":"JESMSGLG","id":2,"record-count":17,
+			 "class":"A","jobname":"TESTJOB","procstep":null},
+			{"recfm":"FBA","stepname":"STEP1","procstep":"COMPILE","byte-count":6732,"lrecl":121,
+			 "jobid":"JOB001","ddname":"SYSPRINT","id":102,"record-count":52,"class":"X","jobname":"TESTJOB"}
+		]`))
+	}))
+	defer server.Close()
+
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	spoolFiles, err := jm.GetSpoolFiles("TESTJOB", "JOB001")
+	require.NoError(t, err)
+	require.Len(t, spoolFiles, 2)
+
+	assert.Equal(t, SpoolFile{
+		ID:         2,
+		DDName:     "JESMSGLG",
+		StepName:   "JES2",
+		Class:      "A",
+		Records:    17,
+		Bytes:      1198,
+		RecFM:      "UA",
+		LRECL:      133,
+		JobName:    "TESTJOB",
+		JobID:      "JOB001",
+		Subsystem:  "JES2",
+		RecordsURL: "https://host:443/zosmf/restjobs/jobs/TESTJOB/JOB001/files/2/records",
+	}, spoolFiles[0])
+	assert.Equal(t, "STEP1", spoolFiles[1].StepName)
+	assert.Equal(t, "COMPILE", spoolFiles[1].ProcStep)
+	assert.Equal(t, "X", spoolFiles[1].Class)
+	assert.Equal(t, 52, spoolFiles[1].Records)
+	assert.Equal(t, 6732, spoolFiles[1].Bytes)
+}
+
 func TestGetSpoolFileContent(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -454,43 +1038,313 @@ func TestGetSpoolFileContent(t *testing.T) {
 	assert.Equal(t, "JES2 JOB LOG OUTPUT", content)
 }
 
-func TestPurgeJob(t *testing.T) {
-	// Create test server
+func TestGetSpoolFileContentRange(t *testing.T) {
+	// Ten records of SYSOUT
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("LINE %02d", i))
+	}
+
+	var encoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Bare job IDs are looked up first
+		switch r.URL.Path {
+		case "/api/v1/restjobs/jobs":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]Job{{JobName: "TESTJOB", JobID: "JOB001"}})
+			return
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB001":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Job{JobName: "TESTJOB", JobID: "JOB001"})
+			return
+		}
+		assert.Equal(t, "/api/v1/restjobs/jobs/TESTJOB/JOB001/files/2/records", r.URL.Path)
+		encoding = r.URL.Query().Get("fileEncoding")
+
+		var start, count int
+		_, err := fmt.Sscanf(r.Header.Get("X-IBM-Record-Range"), "%d,%d", &start, &count)
+		assert.NoError(t, err)
+		end := start + count
+		if end > len(lines) {
+			end = len(lines)
+		}
+		w.Write([]byte(strings.Join(lines[start:end], "\n") + "\n"))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	page, err := jm.GetSpoolFileContentRange("TESTJOB:JOB001", 2, 3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LINE 03", "LINE 04", "LINE 05", "LINE 06"}, page.Records)
+	assert.Equal(t, 3, page.StartRecord)
+	assert.True(t, page.More)
+
+	// Last page
+	page, err = jm.GetSpoolFileContentRange("TESTJOB:JOB001", 2, 8, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LINE 08", "LINE 09"}, page.Records)
+	assert.False(t, page.More)
+
+	// A bare job ID works too
+	page, err = jm.GetSpoolFileContentRange("JOB001", 2, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LINE 00", "LINE 01"}, page.Records)
+	assert.True(t, page.More)
+
+	// Encoding is passed through
+	_, err = jm.GetSpoolFileContentWithOptions("TESTJOB", "JOB001", 2, &SpoolContentOptions{StartRecord: 0, RecordCount: 1, Encoding: "IBM-037"})
+	require.NoError(t, err)
+	assert.Equal(t, "IBM-037", encoding)
+
+	_, err = jm.GetSpoolFileContentRange("TESTJOB:JOB001", 2, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestPurgeJob(t *testing.T) {
+	// Create test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Older servers without /info get the bare purge
+		if r.URL.Path == "/api/v1/info" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restjobs/jobs/JOB001/purge", r.URL.Path)
+		
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Create job manager
+	profile := createTestProfile(server.URL)
+
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+
+	jm := NewJobManager(session)
+
+	// Test purge job
+	err = jm.PurgeJob("JOB001")
+	require.NoError(t, err)
+}
+
+func TestPurgeJobModern(t *testing.T) {
+	var purgeStatus, purgeMessage string
+	infoCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/info" {
+			infoCalls++
+			w.Write([]byte(`{"zosmf_version":"27","zosmf_full_version":"27.0","api_version":"1"}`))
+			return
+		}
+
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restjobs/jobs/TESTJOB1/JOB001", r.URL.Path)
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "purge", body["request"])
+		w.Write([]byte(`{"jobid":"JOB001","jobname":"TESTJOB1","original-jobid":"JOB001","owner":"TESTUSER","member":"JES2","sysname":"SY1","job-correlator":"J0000001SY1.....D5A2B4C1.......:","status":"` + purgeStatus + `","message":"` + purgeMessage + `"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	purgeStatus = "0"
+	require.NoError(t, jm.PurgeJob("TESTJOB1:JOB001"))
+
+	feedback, err := jm.PurgeJobWithFeedback("TESTJOB1:JOB001")
+	require.NoError(t, err)
+	assert.Equal(t, "JOB001", feedback.JobID)
+	assert.Equal(t, "SY1", feedback.Sysname)
+	assert.Equal(t, "0", feedback.Status)
+
+	// Failures reported in the feedback map to typed errors
+	purgeStatus, purgeMessage = "8", "Job is active and cannot be purged"
+	err = jm.PurgeJob("TESTJOB1:JOB001")
+	assert.ErrorIs(t, err, ErrJobActive)
+
+	// Capabilities are only detected once
+	assert.Equal(t, 1, infoCalls)
+}
+
+func TestPurgeJobRetriesDetection(t *testing.T) {
+	infoFails := true
+	infoCalls := 0
+	var purgePaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/info" {
+			infoCalls++
+			if infoFails {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"zosmf_version":"27","zosmf_full_version":"27.0","api_version":"1"}`))
+			return
+		}
+
+		assert.Equal(t, "PUT", r.Method)
+		purgePaths = append(purgePaths, r.URL.Path)
+		w.Write([]byte(`{"jobid":"JOB001","jobname":"TESTJOB1","status":"0"}`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// /info is down: fall back to the legacy purge, and ask again next time
+	require.NoError(t, jm.PurgeJob("TESTJOB1:JOB001"))
+	require.NoError(t, jm.PurgeJob("TESTJOB1:JOB001"))
+	assert.Equal(t, 2, infoCalls)
+
+	// Once it answers, the answer is kept
+	infoFails = false
+	require.NoError(t, jm.PurgeJob("TESTJOB1:JOB001"))
+	require.NoError(t, jm.PurgeJob("TESTJOB1:JOB001"))
+	assert.Equal(t, 3, infoCalls)
+	assert.Equal(t, []string{
+		"/api/v1/restjobs/jobs/TESTJOB1/JOB001/purge",
+		"/api/v1/restjobs/jobs/TESTJOB1/JOB001/purge",
+		"/api/v1/restjobs/jobs/TESTJOB1/JOB001",
+		"/api/v1/restjobs/jobs/TESTJOB1/JOB001",
+	}, purgePaths)
+}
+
+func TestPurgeJobTypedErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"not found", http.StatusNotFound, `{"message":"No job found for reference"}`, ErrJobNotFound},
+		{"active", http.StatusBadRequest, `{"message":"Job is active"}`, ErrJobActive},
+		{"inactive", http.StatusBadRequest, `{"message":"Initiator is inactive"}`, nil},
+		{"not active", http.StatusBadRequest, `{"message":"Job is not active on this system"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			profile := createTestProfile(server.URL)
+			session, err := profile.NewSession()
+			require.NoError(t, err)
+			jm := NewJobManager(session)
+
+			err = jm.PurgeJob("JOB001")
+			if tt.want == nil {
+				assert.Error(t, err)
+				assert.False(t, errors.Is(err, ErrJobActive))
+				return
+			}
+			assert.True(t, errors.Is(err, tt.want))
+		})
+	}
+}
+
+func TestCanPurge(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "PUT", r.Method)
-		assert.Equal(t, "/api/v1/restjobs/jobs/JOB001/purge", r.URL.Path)
-		
-		w.WriteHeader(http.StatusNoContent)
+		assert.Equal(t, "GET", r.Method)
+		switch r.URL.Path {
+		case "/api/v1/restjobs/jobs/DONE/JOB001":
+			json.NewEncoder(w).Encode(Job{JobID: "JOB001", JobName: "DONE", Status: "OUTPUT"})
+		case "/api/v1/restjobs/jobs/RUNNING/JOB002":
+			json.NewEncoder(w).Encode(Job{JobID: "JOB002", JobName: "RUNNING", Status: "ACTIVE"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
 	}))
 	defer server.Close()
 
-	// Create job manager
 	profile := createTestProfile(server.URL)
-
 	session, err := profile.NewSession()
 	require.NoError(t, err)
-
 	jm := NewJobManager(session)
 
-	// Test purge job
-	err = jm.PurgeJob("JOB001")
+	ok, reason, err := jm.CanPurge("DONE:JOB001")
 	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	ok, reason, err = jm.CanPurge("RUNNING:JOB002")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, reason, "active")
+
+	ok, reason, err = jm.CanPurge("GONE:JOB003")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, reason, "not found")
 }
 
 func TestIsJobComplete(t *testing.T) {
-	// Test completed statuses
-	assert.True(t, isJobComplete("OUTPUT"))
-	assert.True(t, isJobComplete("CC 0000"))
-	assert.True(t, isJobComplete("CC 0001"))
-	assert.True(t, isJobComplete("CC 0002"))
-	assert.True(t, isJobComplete("CC 0003"))
-	assert.True(t, isJobComplete("CC 0004"))
-	assert.True(t, isJobComplete("ABEND"))
+	// Only OUTPUT means the job has finished
+	assert.True(t, isJobComplete(&Job{Status: "OUTPUT"}))
+	assert.True(t, isJobComplete(&Job{Status: "OUTPUT", RetCode: "ABEND S322"}))
 
 	// Test active statuses
-	assert.False(t, isJobComplete("ACTIVE"))
-	assert.False(t, isJobComplete("INPUT"))
-	assert.False(t, isJobComplete("RUNNING"))
+	assert.False(t, isJobComplete(&Job{Status: "ACTIVE"}))
+	assert.False(t, isJobComplete(&Job{Status: "INPUT"}))
+	assert.False(t, isJobComplete(&Job{Status: "RUNNING"}))
+}
+
+func TestWaitForJobCompletionResult(t *testing.T) {
+	tests := []struct {
+		name          string
+		retCode       string
+		conditionCode int
+		succeeded     bool
+	}{
+		{"success", "CC 0000", 0, true},
+		{"nonzero condition code", "CC 0008", 8, false},
+		{"JCL error", "JCL ERROR", -1, false},
+		{"system abend", "ABEND S322", -1, false},
+		{"user abend", "ABEND U0100", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				job := Job{JobID: "JOB001", JobName: "TESTJOB1", Status: "ACTIVE"}
+				if polls > 1 {
+					job.Status = "OUTPUT"
+					job.RetCode = tt.retCode
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(job)
+			}))
+			defer server.Close()
+
+			session, err := createTestProfile(server.URL).NewSession()
+			require.NoError(t, err)
+			jm := NewJobManager(session)
+
+			result, err := jm.WaitForJobCompletion("TESTJOB1:JOB001", time.Second, 10*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, 2, polls)
+			assert.Equal(t, "OUTPUT", result.Status)
+			assert.Equal(t, tt.retCode, result.RetCode)
+			assert.Equal(t, tt.conditionCode, result.ConditionCode)
+			assert.Equal(t, tt.succeeded, result.Succeeded)
+		})
+	}
 }
 
 func TestValidateJobRequest(t *testing.T) {
@@ -557,6 +1411,65 @@ func TestCreateJobWithStep(t *testing.T) {
 	assert.Equal(t, expected, job)
 }
 
+func TestFormatDDStatement(t *testing.T) {
+	// Sysout and in-stream data
+	assert.Equal(t, "//SYSPRINT DD SYSOUT=*", FormatDDStatement(DDStatement{Name: "SYSPRINT", Sysout: "*"}))
+	assert.Equal(t, "//SYSIN DD *\n  LISTCAT\n/*", FormatDDStatement(DDStatement{Name: "SYSIN", InStream: []string{"  LISTCAT"}}))
+	assert.Equal(t, "//SYSUT1 DD DUMMY", FormatDDStatement(DDStatement{Name: "SYSUT1", Dummy: true}))
+
+	// Unparenthesized multi-value operands get wrapped
+	assert.Equal(t, "//OUT DD DSN=A.B,DISP=(NEW,CATLG)", FormatDDStatement(DDStatement{Name: "OUT", DSN: "A.B", Disp: "NEW,CATLG"}))
+
+	// Long statements continue on the next line
+	long := FormatDDStatement(DDStatement{
+		Name:  "SYSUT2",
+		DSN:   "TESTUSER.VERY.LONG.OUTPUT.DATASET.NAME",
+		Disp:  "(NEW,CATLG,DELETE)",
+		Space: "(CYL,(10,5),RLSE)",
+		DCB:   "(RECFM=FB,LRECL=80,BLKSIZE=0)",
+	})
+	for _, line := range strings.Split(long, "\n") {
+		assert.LessOrEqual(t, len(line), 71)
+	}
+	assert.Equal(t, "//SYSUT2 DD DSN=TESTUSER.VERY.LONG.OUTPUT.DATASET.NAME,\n"+
+		"//             DISP=(NEW,CATLG,DELETE),SPACE=(CYL,(10,5),RLSE),\n"+
+		"//             DCB=(RECFM=FB,LRECL=80,BLKSIZE=0)", long)
+}
+
+func TestCreateJobWithDDs(t *testing.T) {
+	dds := []DDStatement{
+		{
+			Name: "SYSUT1",
+			DSN:  "TEST.INPUT1",
+			Disp: "SHR",
+			Concatenations: []DDStatement{
+				{Name: "IGNORED", DSN: "TEST.INPUT2", Disp: "SHR"},
+			},
+		},
+		{
+			Name:  "SYSUT2",
+			DSN:   "TEST.OUTPUT",
+			Disp:  "(NEW,CATLG)",
+			Unit:  "SYSDA",
+			Space: "(TRK,(1,1))",
+		},
+		{Name: "SYSPRINT", Sysout: "*"},
+		{Name: "SYSIN", Dummy: true},
+	}
+
+	job := CreateJobWithDDs("TESTJOB", "ACCT", "USER", "A", "(1,1)", "COPY", "IEBGENER", dds)
+
+	expected := "//TESTJOB JOB (ACCT),'USER',MSGCLASS=A,MSGLEVEL=(1,1)\n" +
+		"//COPY EXEC PGM=IEBGENER\n" +
+		"//SYSUT1 DD DSN=TEST.INPUT1,DISP=SHR\n" +
+		"// DD DSN=TEST.INPUT2,DISP=SHR\n" +
+		"//SYSUT2 DD DSN=TEST.OUTPUT,DISP=(NEW,CATLG),UNIT=SYSDA,\n" +
+		"//             SPACE=(TRK,(1,1))\n" +
+		"//SYSPRINT DD SYSOUT=*\n" +
+		"//SYSIN DD DUMMY\n"
+	assert.Equal(t, expected, job)
+}
+
 func TestGetJobsByOwner(t *testing.T) {
 	// Create test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -851,6 +1764,70 @@ func TestPurgeJobErrors(t *testing.T) {
 	assert.Contains(t, err.Error(), "API request failed with status 400")
 }
 
+func TestSubmitJobAndWait(t *testing.T) {
+	newServer := func(finalStatus, retCode string) (*httptest.Server, *int) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == "PUT" {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"jobid":"JOB001","jobname":"TESTJOB","owner":"testuser","status":"INPUT"}`))
+				return
+			}
+
+			assert.Equal(t, "/api/v1/restjobs/jobs/TESTJOB/JOB001", r.URL.Path)
+			polls++
+			job := Job{JobID: "JOB001", JobName: "TESTJOB", Owner: "testuser", Status: "ACTIVE"}
+			if polls > 1 {
+				job.Status = finalStatus
+				job.RetCode = retCode
+			}
+			json.NewEncoder(w).Encode(job)
+		}))
+		return server, &polls
+	}
+	request := &SubmitJobRequest{JobStatement: "//TESTJOB JOB (ACCT)"}
+
+	t.Run("completes", func(t *testing.T) {
+		server, polls := newServer("OUTPUT", "CC 0000")
+		defer server.Close()
+		session, err := createTestProfile(server.URL).NewSession()
+		require.NoError(t, err)
+		jm := NewJobManager(session)
+
+		job, err := jm.SubmitJobAndWait(request, time.Second, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, "OUTPUT", job.Status)
+		assert.Equal(t, "CC 0000", job.RetCode)
+		assert.Equal(t, 2, *polls)
+	})
+
+	t.Run("JCL error", func(t *testing.T) {
+		server, _ := newServer("OUTPUT", "JCL ERROR")
+		defer server.Close()
+		session, err := createTestProfile(server.URL).NewSession()
+		require.NoError(t, err)
+		jm := NewJobManager(session)
+
+		job, err := jm.SubmitJobAndWait(request, time.Second, 10*time.Millisecond)
+		assert.ErrorIs(t, err, ErrJCLError)
+		require.NotNil(t, job)
+		assert.Equal(t, "JCL ERROR", job.RetCode)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server, _ := newServer("ACTIVE", "")
+		defer server.Close()
+		session, err := createTestProfile(server.URL).NewSession()
+		require.NoError(t, err)
+		jm := NewJobManager(session)
+
+		_, err = jm.SubmitJobAndWait(request, 50*time.Millisecond, 10*time.Millisecond)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timeout waiting for job TESTJOB:JOB001")
+	})
+}
+
 func TestWaitForJobCompletionTimeout(t *testing.T) {
 	// Create test server that always returns running status
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1049,3 +2026,623 @@ func TestGetJobInfoError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "API request failed with status 404")
 }
+
+func TestJobWatcherPoll(t *testing.T) {
+	// Each poll returns the next snapshot
+	snapshots := [][]Job{
+		{{JobID: "JOB001", JobName: "A", Status: "ACTIVE"}},
+		{{JobID: "JOB001", JobName: "A", Status: "OUTPUT", RetCode: "CC 0000"}, {JobID: "JOB002", JobName: "B", Status: "INPUT"}, {JobID: "JOB002", JobName: "B", Status: "INPUT"}},
+		{{JobID: "JOB002", JobName: "B", Status: "INPUT"}},
+		{{JobID: "JOB002", JobName: "B", Status: "INPUT"}},
+	}
+	poll := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/restjobs/jobs", r.URL.Path)
+		assert.Equal(t, "TESTUSER", r.URL.Query().Get("owner"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshots[poll])
+		poll++
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	watcher := jm.NewJobWatcher(&JobFilter{Owner: "TESTUSER"}, time.Hour)
+
+	// First poll: everything is new
+	events, err := watcher.Poll()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, JobAdded, events[0].Type)
+	assert.Equal(t, "JOB001", events[0].Job.JobID)
+
+	// Second poll: A finished, B appeared once despite being listed twice
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, JobStatusChanged, events[0].Type)
+	assert.Equal(t, "ACTIVE", events[0].PreviousStatus)
+	assert.Equal(t, "OUTPUT", events[0].Job.Status)
+	assert.Equal(t, JobAdded, events[1].Type)
+	assert.Equal(t, "JOB002", events[1].Job.JobID)
+
+	// Third poll: A was purged
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, JobRemoved, events[0].Type)
+	assert.Equal(t, "JOB001", events[0].Job.JobID)
+
+	// Fourth poll: nothing changed
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestJobWatcherStartStop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Job{{JobID: "JOB001", JobName: "A", Status: "ACTIVE"}})
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	watcher := jm.NewJobWatcher(nil, 10*time.Millisecond)
+	watcher.Start()
+
+	select {
+	case event := <-watcher.Events():
+		assert.Equal(t, JobAdded, event.Type)
+		assert.Equal(t, "JOB001", event.Job.JobID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	watcher.Stop()
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+}
+
+func TestJobWatcherStopWithoutStart(t *testing.T) {
+	watcher := NewJobManager(nil).NewJobWatcher(nil, time.Hour)
+
+	stopped := make(chan struct{})
+	go func() {
+		watcher.Stop()
+		watcher.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop blocked without Start")
+	}
+
+	_, open := <-watcher.Events()
+	assert.False(t, open)
+
+	// A stopped watcher doesn't start polling again
+	watcher.Start()
+	_, open = <-watcher.Events()
+	assert.False(t, open)
+}
+
+func TestJobWatcherErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	profile := createTestProfile(server.URL)
+	session, err := profile.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	watcher := jm.NewJobWatcher(nil, 10*time.Millisecond)
+	watcher.Start()
+	defer watcher.Stop()
+
+	select {
+	case err := <-watcher.Errors():
+		assert.Contains(t, err.Error(), "API request failed with status 500")
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+}
+
+func TestWaitForJobCompletionAsync(t *testing.T) {
+	var mu sync.Mutex
+	status := "ACTIVE"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Job{JobID: "JOB00001", JobName: "TESTJOB", Status: status, RetCode: "CC 0000"})
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	t.Run("cancel", func(t *testing.T) {
+		results, cancel := jm.WaitForJobCompletionAsync("TESTJOB:JOB00001", time.Hour)
+		cancel()
+		cancel() // Safe to call twice
+
+		select {
+		case result := <-results:
+			assert.ErrorIs(t, result.Err, ErrWaitCanceled)
+			assert.Nil(t, result.Result)
+		case <-time.After(5 * time.Second):
+			t.Fatal("cancel did not stop the wait")
+		}
+		_, open := <-results
+		assert.False(t, open, "channel should be closed after the result")
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		results, cancel := jm.WaitForJobCompletionAsync("TESTJOB:JOB00001", 10*time.Millisecond)
+		defer cancel()
+
+		mu.Lock()
+		status = "OUTPUT"
+		mu.Unlock()
+
+		select {
+		case result := <-results:
+			require.NoError(t, result.Err)
+			assert.True(t, result.Result.Succeeded)
+		case <-time.After(5 * time.Second):
+			t.Fatal("wait did not complete")
+		}
+	})
+}
+
+func TestListJobsActiveExecData(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"jobid":"JOB00001","jobname":"TESTJOB","status":"ACTIVE","phase-name":"Job is actively executing","exec-system":"SYS1","exec-started":"2024-05-01T10:00:00.000Z"}]`))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	jobList, err := jm.ListJobs(&JobFilter{Owner: "IBMUSER", MaxJobs: 50, ActiveOnly: true, ExecData: true})
+	require.NoError(t, err)
+	assert.Equal(t, "active", query.Get("status"))
+	assert.Equal(t, "Y", query.Get("exec-data"))
+	assert.Equal(t, "50", query.Get("max-jobs"))
+	require.Len(t, jobList.Jobs, 1)
+	assert.Equal(t, "SYS1", jobList.Jobs[0].ExecSystem)
+	assert.Equal(t, "2024-05-01T10:00:00.000Z", jobList.Jobs[0].ExecStarted)
+
+	// Neither option is sent unless asked for
+	_, err = jm.ListJobs(&JobFilter{Owner: "IBMUSER"})
+	require.NoError(t, err)
+	assert.Empty(t, query.Get("status"))
+	assert.Empty(t, query.Get("exec-data"))
+}
+
+func TestListJobsPages(t *testing.T) {
+	var allJobs []Job
+	for i := 1; i <= 5; i++ {
+		allJobs = append(allJobs, Job{JobID: fmt.Sprintf("JOB%05d", i), JobName: "TESTJOB", Status: "OUTPUT"})
+	}
+	var maxJobs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		max := r.URL.Query().Get("max-jobs")
+		maxJobs = append(maxJobs, max)
+		n, _ := strconv.Atoi(max)
+		if n > len(allJobs) {
+			n = len(allJobs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(allJobs[:n])
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	var pages [][]string
+	err = jm.ListJobsPages(&JobFilter{Owner: "IBMUSER"}, 2, func(page []Job) bool {
+		var ids []string
+		for _, job := range page {
+			ids = append(ids, job.JobID)
+		}
+		pages = append(pages, ids)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"JOB00001", "JOB00002"}, {"JOB00003", "JOB00004"}, {"JOB00005"}}, pages)
+	assert.Equal(t, []string{"2", "4", "6"}, maxJobs)
+
+	// MaxJobs caps the total
+	maxJobs = nil
+	count := 0
+	err = jm.ListJobsPages(&JobFilter{Owner: "IBMUSER", MaxJobs: 3}, 2, func(page []Job) bool {
+		count += len(page)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"2", "3"}, maxJobs)
+}
+
+func TestGetJobOutputPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]SpoolFile{
+				{ID: 2, DDName: "JESMSGLG"},
+				{ID: 3, DDName: "JESJCL"},
+				{ID: 4, DDName: "JESYSMSG"},
+			})
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.URL.Path, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/%d/records", &id)
+		if id == 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "content %d", id)
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// The readable files still come back; the failure is reported, not dropped
+	output, err := jm.GetJobOutput("TESTJOB:JOB00001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spool file 3 (JESJCL)")
+	assert.Equal(t, map[string]string{
+		"JESMSGLG": "content 2",
+		"JESYSMSG": "content 4",
+	}, output)
+}
+
+func TestResolveJobByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/restjobs/jobs":
+			jobs := []Job{}
+			if strings.EqualFold(r.URL.Query().Get("jobid"), "JOB00001") {
+				jobs = append(jobs, Job{JobName: "TESTJOB", JobID: "JOB00001"})
+			}
+			json.NewEncoder(w).Encode(jobs)
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB00001":
+			json.NewEncoder(w).Encode(Job{JobName: "TESTJOB", JobID: "JOB00001", Status: "OUTPUT"})
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files":
+			json.NewEncoder(w).Encode([]SpoolFile{{ID: 2, DDName: "JESMSGLG"}})
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "content")
+		}
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// Bare IDs match regardless of case
+	output, err := jm.GetJobOutput("job00001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"JESMSGLG": "content"}, output)
+
+	// An unknown ID is ErrJobNotFound from every helper that takes one
+	_, err = jm.GetJobOutput("JOB99999")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	_, err = jm.DownloadJobOutput("JOB99999", t.TempDir())
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	_, err = jm.GetSpoolContentByStepDD("JOB99999", "STEP1", "SYSPRINT")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	err = jm.TailJobOutput(context.Background(), "JOB99999", "SYSPRINT", io.Discard, time.Millisecond)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestDownloadJobOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]SpoolFile{
+				{ID: 2, DDName: "JESMSGLG", StepName: "JES2"},
+				{ID: 3, DDName: "JESJCL", StepName: "JES2"},
+				{ID: 102, DDName: "SYSPRINT", StepName: "STEP1"},
+			})
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.URL.Path, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/%d/records", &id)
+		fmt.Fprintf(w, "content %d", id)
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	dir := filepath.Join(t.TempDir(), "output")
+	paths, err := jm.DownloadJobOutput("TESTJOB:JOB00001", dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "JES2.JESMSGLG.txt"),
+		filepath.Join(dir, "JES2.JESJCL.txt"),
+		filepath.Join(dir, "STEP1.SYSPRINT.txt"),
+	}, paths)
+
+	data, err := os.ReadFile(filepath.Join(dir, "STEP1.SYSPRINT.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "content 102", string(data))
+}
+
+func TestGetSpoolContentByStepDD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]SpoolFile{
+				{ID: 2, DDName: "JESMSGLG", StepName: "JES2"},
+				{ID: 102, DDName: "SYSPRINT", StepName: "STEP1"},
+				{ID: 103, DDName: "SYSPRINT", StepName: "STEP2", ProcStep: "COMPILE"},
+				{ID: 104, DDName: "SYSPRINT", StepName: "STEP2", ProcStep: "LKED"},
+			})
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.URL.Path, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/%d/records", &id)
+		fmt.Fprintf(w, "content %d", id)
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	content, err := jm.GetSpoolContentByStepDD("TESTJOB:JOB00001", "STEP1", "SYSPRINT")
+	require.NoError(t, err)
+	assert.Equal(t, "content 102", content)
+
+	content, err = jm.GetSpoolContentByStepDD("TESTJOB:JOB00001", "STEP2.LKED", "SYSPRINT")
+	require.NoError(t, err)
+	assert.Equal(t, "content 104", content)
+
+	_, err = jm.GetSpoolContentByStepDD("TESTJOB:JOB00001", "STEP3", "SYSPRINT")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "STEP3.SYSPRINT not found")
+}
+
+func TestTailJobOutput(t *testing.T) {
+	// Each status poll advances the job: the DD appears on the second poll,
+	// grows over the next ones, and the job ends on the fourth. STEP1 and
+	// STEP2 both write a SYSPRINT.
+	states := []struct {
+		status  string
+		records []string
+	}{
+		{"ACTIVE", nil},
+		{"ACTIVE", []string{"LINE 1", "LINE 2"}},
+		{"ACTIVE", []string{"LINE 1", "LINE 2", "LINE 3"}},
+		{"OUTPUT", []string{"LINE 1", "LINE 2", "LINE 3", "LINE 4", "LINE 5"}},
+	}
+	polls := 0
+	var ranges []string
+	var tailed []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB00001":
+			polls++
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(Job{JobName: "TESTJOB", JobID: "JOB00001", Status: states[polls-1].status})
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files":
+			files := []SpoolFile{{ID: 2, DDName: "JESMSGLG"}}
+			if states[polls-1].records != nil {
+				files = append(files,
+					SpoolFile{ID: 101, DDName: "SYSPRINT", StepName: "STEP1"},
+					SpoolFile{ID: 102, DDName: "SYSPRINT", StepName: "STEP2"})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(files)
+		case "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/101/records",
+			"/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/102/records":
+			tailed = append(tailed, r.URL.Path)
+			recordRange := r.Header.Get("X-IBM-Record-Range")
+			ranges = append(ranges, recordRange)
+			var start, count int
+			fmt.Sscanf(recordRange, "%d,%d", &start, &count)
+			records := states[polls-1].records
+			for i := start; i < len(records) && i < start+count; i++ {
+				fmt.Fprintf(w, "%s\n", records[i])
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	// Without a step, the first SYSPRINT is followed
+	var out strings.Builder
+	err = jm.TailJobOutput(context.Background(), "TESTJOB:JOB00001", "SYSPRINT", &out, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "LINE 1\nLINE 2\nLINE 3\nLINE 4\nLINE 5\n", out.String())
+	assert.Equal(t, []string{"0,5000", "2,5000", "3,5000"}, ranges)
+	assert.Equal(t, len(states), polls)
+	assert.Contains(t, tailed, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/101/records")
+	assert.NotContains(t, tailed, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/102/records")
+
+	// STEP2's SYSPRINT, with STEP1's left alone
+	polls, ranges, tailed = 0, nil, nil
+	out.Reset()
+	err = jm.TailJobOutputByStepDD(context.Background(), "TESTJOB:JOB00001", "STEP2", "SYSPRINT", &out, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "LINE 1\nLINE 2\nLINE 3\nLINE 4\nLINE 5\n", out.String())
+	assert.Equal(t, []string{"0,5000", "2,5000", "3,5000"}, ranges)
+	assert.Equal(t, len(states), polls)
+	assert.NotContains(t, tailed, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/101/records")
+}
+
+func TestTailJobOutputCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		json.NewEncoder(w).Encode(Job{JobName: "TESTJOB", JobID: "JOB00001", Status: "ACTIVE"})
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = jm.TailJobOutput(ctx, "TESTJOB:JOB00001", "SYSPRINT", io.Discard, time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTailJobOutputCanceledMidRequest(t *testing.T) {
+	// The status request never answers, so only ctx reaching the HTTP call
+	// can end the tail
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = jm.TailJobOutput(ctx, "TESTJOB:JOB00001", "SYSPRINT", io.Discard, time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestSpoolFileName(t *testing.T) {
+	assert.Equal(t, "STEP1.SYSUT2", spoolFileName(SpoolFile{StepName: "STEP1", DDName: "SYSUT2"}))
+	assert.Equal(t, "JES2.JESYSMSG", spoolFileName(SpoolFile{DDName: "JESYSMSG"}))
+}
+
+func TestGetJobOutputConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files" {
+			w.Header().Set("Content-Type", "application/json")
+			var files []SpoolFile
+			for id := 1; id <= 6; id++ {
+				files = append(files, SpoolFile{ID: id, DDName: fmt.Sprintf("DD%d", id)})
+			}
+			json.NewEncoder(w).Encode(files)
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.URL.Path, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/%d/records", &id)
+		if id == 4 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		// Earlier files answer slowest so completion order is reversed
+		time.Sleep(time.Duration(7-id) * 5 * time.Millisecond)
+		fmt.Fprintf(w, "content %d", id)
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	sections, err := jm.GetJobOutputConcurrent("TESTJOB:JOB00001", 6)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spool file 4 (DD4)")
+	require.Len(t, sections, 6)
+	for i, section := range sections {
+		assert.Equal(t, i+1, section.SpoolFile.ID)
+		if section.SpoolFile.ID == 4 {
+			assert.Error(t, section.Err)
+			continue
+		}
+		assert.NoError(t, section.Err)
+		assert.Equal(t, fmt.Sprintf("content %d", i+1), section.Content)
+	}
+}
+
+func TestSpoolContentModes(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/restjobs/jobs/TESTJOB/JOB00001/files/2/records", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte("HELLO"))
+	}))
+	defer server.Close()
+
+	p := createTestProfile(server.URL)
+	session, err := p.NewSession()
+	require.NoError(t, err)
+	jm := NewJobManager(session)
+
+	_, err = jm.GetSpoolFileText("TESTJOB:JOB00001", 2, 37)
+	require.NoError(t, err)
+	assert.Equal(t, "IBM-037", query.Get("fileEncoding"))
+	assert.Empty(t, query.Get("mode"))
+
+	_, err = jm.GetSpoolFileText("TESTJOB:JOB00001", 2, 1047)
+	require.NoError(t, err)
+	assert.Equal(t, "IBM-1047", query.Get("fileEncoding"))
+
+	_, err = jm.GetSpoolFileContentWithOptions("TESTJOB", "JOB00001", 2, &SpoolContentOptions{Mode: SpoolModeBinary})
+	require.NoError(t, err)
+	assert.Equal(t, "binary", query.Get("mode"))
+	assert.Empty(t, query.Get("fileEncoding"))
+
+	_, err = jm.GetSpoolFileContentWithOptions("TESTJOB", "JOB00001", 2, &SpoolContentOptions{Mode: SpoolModeRecord})
+	require.NoError(t, err)
+	assert.Equal(t, "record", query.Get("mode"))
+
+	// Raw modes can't be combined with an encoding
+	_, err = jm.GetSpoolFileContentWithOptions("TESTJOB", "JOB00001", 2, &SpoolContentOptions{Mode: SpoolModeBinary, Encoding: "IBM-1047"})
+	assert.Error(t, err)
+	_, err = jm.GetSpoolFileContentWithOptions("TESTJOB", "JOB00001", 2, &SpoolContentOptions{Mode: "bogus"})
+	assert.Error(t, err)
+}
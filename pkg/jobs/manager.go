@@ -2,11 +2,14 @@ package jobs
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -27,6 +30,9 @@ const (
 	CancelEndpoint  = "/cancel"
 	PurgeEndpoint   = "/purge"
 	RecordsEndpoint = "/records"
+
+	// z/OSMF server information, used for capability detection
+	InfoEndpoint = "/info"
 	
 	// File operations
 	JobFilesEndpoint        = "/files"
@@ -34,6 +40,9 @@ const (
 	JobFilesJCLEndpoint     = "/files/JCL/records"
 )
 
+// DefaultMaxJobs is the max-jobs limit z/OSMF applies when none is given
+const DefaultMaxJobs = 1000
+
 // NewJobManager creates a job manager with the given session
 func NewJobManager(session *profile.Session) *ZOSMFJobManager {
 	return &ZOSMFJobManager{
@@ -41,6 +50,15 @@ func NewJobManager(session *profile.Session) *ZOSMFJobManager {
 	}
 }
 
+// getSession returns the manager's session, or profile.ErrNoSession when
+// the manager was created with a nil one
+func (jm *ZOSMFJobManager) getSession() (*profile.Session, error) {
+	if jm.session == nil {
+		return nil, profile.ErrNoSession
+	}
+	return jm.session, nil
+}
+
 // NewJobManagerFromProfile creates a job manager from a profile
 func NewJobManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFJobManager, error) {
 	session, err := profile.NewSession()
@@ -52,7 +70,10 @@ func NewJobManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFJobManager,
 
 // ListJobs gets jobs matching the filter
 func (jm *ZOSMFJobManager) ListJobs(filter *JobFilter) (*JobList, error) {
-	session := jm.session.(*profile.Session)
+	session, err := jm.getSession()
+	if err != nil {
+		return nil, err
+	}
 	
 	// Build query parameters
 	params := url.Values{}
@@ -78,88 +99,96 @@ func (jm *ZOSMFJobManager) ListJobs(filter *JobFilter) (*JobList, error) {
 		if filter.UserCorrelator != "" {
 			params.Set("user-correlator", filter.UserCorrelator)
 		}
+		if filter.ActiveOnly {
+			params.Set("status", "active")
+		}
+		if filter.ExecData || filter.filtersSubmitted() {
+			params.Set("exec-data", "Y")
+		}
 	}
 
-	// Build URL
-	apiURL := session.GetBaseURL() + JobsEndpoint
+	path := JobsEndpoint
 	if len(params) > 0 {
-		apiURL += "?" + params.Encode()
+		path += "?" + params.Encode()
 	}
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	var raw json.RawMessage
+	if err := session.DoJSON(context.Background(), "GET", path, nil, &raw); err != nil {
+		return nil, err
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	// Parse response with fallback for array responses
+	bodyBytes := []byte(raw)
+	// First try object with jobs field
+	var jobList JobList
+	if err := profile.DecodeJSON(bodyBytes, &jobList, jm.StrictJSON); err != nil || (len(jobList.Jobs) == 0 && string(bodyBytes) != "{}") {
+		// Fallback: direct array response
+		var jobsArr []Job
+		if err := profile.DecodeJSON(bodyBytes, &jobsArr, jm.StrictJSON); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %s", session.Redact(string(bodyBytes)))
+		}
+		jobList = JobList{Jobs: jobsArr}
 	}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+	maxJobs := DefaultMaxJobs
+	if filter != nil && filter.MaxJobs > 0 {
+		maxJobs = filter.MaxJobs
 	}
-	defer resp.Body.Close()
+	jobList.Truncated = len(jobList.Jobs) >= maxJobs
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	populateExecTimes(jobList.Jobs)
+	if filter != nil && filter.filtersSubmitted() {
+		jobList.Jobs = filter.matchSubmitted(jobList.Jobs)
 	}
 
-	// Parse response with fallback for array responses
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-	// First try object with jobs field
-	var jobList JobList
-	if err := json.Unmarshal(bodyBytes, &jobList); err == nil && (len(jobList.Jobs) > 0 || string(bodyBytes) == "{}") {
-		return &jobList, nil
-	}
-	// Fallback: direct array response
-	var jobsArr []Job
-	if err := json.Unmarshal(bodyBytes, &jobsArr); err == nil {
-		return &JobList{Jobs: jobsArr}, nil
-	}
-	return nil, fmt.Errorf("failed to decode response: %s", string(bodyBytes))
+	return &jobList, nil
 }
 
-// GetJob retrieves detailed information about a specific job by correlator or job ID
+// GetJob retrieves a job given any of the forms z/OSMF identifies jobs by:
+// "jobname:jobid", a bare JES job ID such as JOB01234 (resolved with
+// GetJobByID) or a z/OSMF job correlator (looked up with GetJobByCorrelator)
 func (jm *ZOSMFJobManager) GetJob(correlator string) (*Job, error) {
-	// Check if it's already in correlator format (jobname:jobid)
-	if strings.Contains(correlator, ":") {
-		// Parse correlator to get jobname and jobid
-		jobName, jobID, err := parseCorrelator(correlator)
-		if err != nil {
-			return nil, fmt.Errorf("invalid correlator format: %w", err)
-		}
+	// jobname:jobid; z/OSMF correlators also hold a colon, but as their last character
+	if jobName, jobID, err := parseCorrelator(correlator); err == nil && jobName != "" && jobID != "" {
 		return jm.GetJobByNameID(jobName, jobID)
 	}
 	
-	// If it's just a job ID, we need to find the job first
-	// List jobs and find the one with this job ID
+	if isJobID(correlator) {
+		return jm.GetJobByID(correlator)
+	}
+	return jm.GetJobByCorrelator(correlator)
+}
+
+// GetJobByID retrieves a job by its bare JES job ID, listing jobs with that
+// ID first to learn the job name the name/id endpoint needs
+func (jm *ZOSMFJobManager) GetJobByID(jobID string) (*Job, error) {
 	jobFilter := &JobFilter{
-		JobID: correlator,
+		JobID: jobID,
 		MaxJobs: 100, // Get more jobs to find the one we need
 	}
 	
 	jobList, err := jm.ListJobs(jobFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find job with ID %s: %w", correlator, err)
+		return nil, fmt.Errorf("failed to find job with ID %s: %w", jobID, err)
 	}
 	
 	// Find the job with the specified job ID
 	for _, job := range jobList.Jobs {
-		if job.JobID == correlator {
+		if strings.EqualFold(job.JobID, jobID) {
 			return jm.GetJobByNameID(job.JobName, job.JobID)
 		}
 	}
 	
-	return nil, fmt.Errorf("job with ID %s not found", correlator)
+	return nil, fmt.Errorf("%w: job with ID %s not found", ErrJobNotFound, jobID)
+}
+
+// jobIDPattern matches JES job IDs: JOB/STC/TSU with five digits, or
+// J/S/T with seven once the system goes past 99,999 jobs
+var jobIDPattern = regexp.MustCompile(`(?i)^((JOB|STC|TSU)[0-9]{5}|[JST][0-9]{7})$`)
+
+// isJobID reports whether s is a bare JES job ID rather than a z/OSMF correlator
+func isJobID(s string) bool {
+	return jobIDPattern.MatchString(s)
 }
 
 // GetJobInfo retrieves job information
@@ -170,39 +199,15 @@ func (jm *ZOSMFJobManager) GetJobInfo(correlator string) (*JobInfo, error) {
 		return nil, fmt.Errorf("invalid correlator format: %w", err)
 	}
 	
-	session := jm.session.(*profile.Session)
-	
-	// Build URL using jobname/jobid format
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + JobFilesEndpoint
-
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	session, err := jm.getSession()
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
-
-	// Parse response
+	
 	var jobInfo JobInfo
-	if err := json.NewDecoder(resp.Body).Decode(&jobInfo); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + JobFilesEndpoint
+	if err := jm.getJSON(context.Background(), session, path, &jobInfo); err != nil {
+		return nil, err
 	}
 
 	return &jobInfo, nil
@@ -219,71 +224,52 @@ func (jm *ZOSMFJobManager) GetJobStatus(correlator string) (string, error) {
 
 // GetJobByNameID retrieves a job by job name and job id
 func (jm *ZOSMFJobManager) GetJobByNameID(jobName, jobID string) (*Job, error) {
-	session := jm.session.(*profile.Session)
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID))
+	return jm.getJobByNameID(context.Background(), jobName, jobID)
+}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-	resp, err := session.GetHTTPClient().Do(req)
+// getJobByNameID is GetJobByNameID bounded by ctx
+func (jm *ZOSMFJobManager) getJobByNameID(ctx context.Context, jobName, jobID string) (*Job, error) {
+	session, err := jm.getSession()
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
+
 	var job Job
-	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID))
+	if err := jm.getJSON(ctx, session, path, &job); err != nil {
+		var apiErr *profile.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", ErrJobNotFound, apiErr)
+		}
+		return nil, err
 	}
 	return &job, nil
 }
 
 // GetJobByCorrelator retrieves a job by correlator
 func (jm *ZOSMFJobManager) GetJobByCorrelator(correlator string) (*Job, error) {
-	session := jm.session.(*profile.Session)
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByCorrelatorEndpoint, url.PathEscape(correlator))
-
-	req, err := http.NewRequest("GET", apiURL, nil)
+	session, err := jm.getSession()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
+
 	var job Job
-	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := jm.getJSON(context.Background(), session, fmt.Sprintf(JobByCorrelatorEndpoint, url.PathEscape(correlator)), &job); err != nil {
+		return nil, err
 	}
 	return &job, nil
 }
 
 // SubmitJob submits a new job
 func (jm *ZOSMFJobManager) SubmitJob(request *SubmitJobRequest) (*SubmitJobResponse, error) {
-	session := jm.session.(*profile.Session)
+	session, err := jm.getSession()
+	if err != nil {
+		return nil, err
+	}
 	
-	// Build URL
-	apiURL := session.GetBaseURL() + JobsEndpoint
-
 	// Prepare request body and content type based on submission type
 	var requestBody []byte
 	var contentType string
-	var err error
 	
 	if request.JobStatement != "" {
 		// Submit job statement as plain text (z/OSMF expects JCL as text/plain for direct submission)
@@ -328,72 +314,147 @@ func (jm *ZOSMFJobManager) SubmitJob(request *SubmitJobRequest) (*SubmitJobRespo
 		return nil, fmt.Errorf("no job source specified (jobStatement, jobDataSet, or jobLocalFile)")
 	}
 
-	// Create request (use PUT per z/OSMF documentation)
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(requestBody))
+	// Check JCL symbols before anything is sent
+	if err := validateJCLSymbols(request.Symbols); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"Content-Type": contentType}
+	for name, value := range request.Symbols {
+		headers["X-IBM-JCL-Symbol-"+strings.ToUpper(name)] = value
+	}
+
+	return jm.doSubmit(session, bytes.NewReader(requestBody), headers)
+}
+
+// SubmitJCL streams JCL held by the client to the JES internal reader.
+// A nil opts uses class A, fixed 80-byte records.
+func (jm *ZOSMFJobManager) SubmitJCL(jcl io.Reader, opts *IntrdrOptions) (*SubmitJobResponse, error) {
+	session, err := jm.getSession()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	o := IntrdrOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Class == "" {
+		o.Class = DefaultIntrdrClass
+	}
+	if o.RecFm == "" {
+		o.RecFm = DefaultIntrdrRecFm
 	}
-	req.Header.Set("Content-Type", contentType)
+	if o.LRecl == 0 {
+		o.LRecl = DefaultIntrdrLRecl
+	}
+
+	return jm.doSubmit(session, jcl, map[string]string{
+		"Content-Type":       "text/plain",
+		"X-IBM-Intrdr-Class": o.Class,
+		"X-IBM-Intrdr-Recfm": o.RecFm,
+		"X-IBM-Intrdr-Lrecl": strconv.Itoa(o.LRecl),
+		"X-IBM-Intrdr-Mode":  "TEXT",
+	})
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+// doSubmit PUTs a job to the jobs endpoint (per z/OSMF documentation) and
+// parses the response
+func (jm *ZOSMFJobManager) doSubmit(session *profile.Session, body io.Reader, headers map[string]string) (*SubmitJobResponse, error) {
+	resp, err := session.DoRawWithHeaders(context.Background(), "PUT", JobsEndpoint, body, headers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// Parse response. Some z/OSMF levels return a sparse or empty body and
+	// carry the job identity only in the feedback headers.
+	bodyBytes, err := session.ReadBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Parse response
 	var submitResponse SubmitJobResponse
-	if err := json.NewDecoder(resp.Body).Decode(&submitResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if len(bytes.TrimSpace(bodyBytes)) > 0 {
+		if err := profile.DecodeJSON(bodyBytes, &submitResponse, jm.StrictJSON); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
 	}
+	applySubmitHeaders(resp.Header, &submitResponse)
 
 	return &submitResponse, nil
 }
 
-// CancelJob cancels a running job
-func (jm *ZOSMFJobManager) CancelJob(correlator string) error {
-	session := jm.session.(*profile.Session)
-	
-	// Build URL
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByCorrelatorEndpoint, url.PathEscape(correlator)) + CancelEndpoint
+// applySubmitHeaders fills a submit response from the z/OSMF feedback
+// headers, which take precedence over the body when present
+func applySubmitHeaders(header http.Header, submitResponse *SubmitJobResponse) {
+	if location := header.Get("Location"); location != "" {
+		submitResponse.URL = location
+		// Location ends in .../restjobs/jobs/<jobname>/<jobid>
+		if i := strings.Index(location, JobsEndpoint+"/"); i >= 0 {
+			parts := strings.Split(strings.Trim(location[i+len(JobsEndpoint):], "/"), "/")
+			if len(parts) == 2 {
+				submitResponse.JobName = parts[0]
+				submitResponse.JobID = parts[1]
+			}
+		}
+	}
+	if jobID := header.Get("X-IBM-Job-Id"); jobID != "" {
+		submitResponse.JobID = jobID
+	}
+	if jobName := header.Get("X-IBM-Job-Name"); jobName != "" {
+		submitResponse.JobName = jobName
+	}
+	if correlator := header.Get("X-IBM-Job-Correlator"); correlator != "" {
+		submitResponse.JobCorrelator = correlator
+	}
+}
 
-	// Create request
-	req, err := http.NewRequest("PUT", apiURL, nil)
+// CancelJob cancels a running job. Accepts either jobname:jobid or a z/OSMF
+// job correlator.
+func (jm *ZOSMFJobManager) CancelJob(correlator string) error {
+	session, err := jm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	path, err := jobPath(correlator)
+	if err != nil {
+		return err
 	}
+	return session.DoJSON(context.Background(), "PUT", path+CancelEndpoint, nil, nil)
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+// HoldJob holds a job so JES won't select it for execution. Accepts either
+// jobname:jobid or a z/OSMF job correlator.
+func (jm *ZOSMFJobManager) HoldJob(correlator string) error {
+	return jm.modifyJob(correlator, "hold")
+}
+
+// ReleaseJob releases a previously held job. Accepts either jobname:jobid
+// or a z/OSMF job correlator.
+func (jm *ZOSMFJobManager) ReleaseJob(correlator string) error {
+	return jm.modifyJob(correlator, "release")
+}
+
+// modifyJob sends a {"request": ...} job modification
+func (jm *ZOSMFJobManager) modifyJob(correlator, request string) error {
+	session, err := jm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	path, err := jobPath(correlator)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	err = session.DoJSON(context.Background(), "PUT", path, map[string]string{"request": request}, nil)
+	var apiErr *profile.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrJobNotFound, apiErr)
+	}
+	return err
 }
 
 // DeleteJob deletes a job using correlator format (jobname:jobid)
@@ -409,73 +470,33 @@ func (jm *ZOSMFJobManager) DeleteJob(correlator string) error {
 
 // DeleteJobByNameID deletes a job using separate jobName and jobID
 func (jm *ZOSMFJobManager) DeleteJobByNameID(jobName, jobID string) error {
-	session := jm.session.(*profile.Session)
-	
-	// Build URL using jobName and jobID format
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID))
-
-	// Create request
-	req, err := http.NewRequest("DELETE", apiURL, nil)
+	session, err := jm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
-
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	
+	// Address the job by jobName and jobID
+	path := fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID))
+	return session.DoJSON(context.Background(), "DELETE", path, nil, nil)
 }
 
 // GetSpoolFiles retrieves spool files for a job using jobname and jobid
 func (jm *ZOSMFJobManager) GetSpoolFiles(jobName, jobID string) ([]SpoolFile, error) {
-	session := jm.session.(*profile.Session)
-	
-	// Build URL using the correct z/OSMF format: /restjobs/jobs/{jobname}/{jobid}/files
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + JobFilesEndpoint
-
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
-	}
+	return jm.getSpoolFiles(context.Background(), jobName, jobID)
+}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+// getSpoolFiles is GetSpoolFiles bounded by ctx
+func (jm *ZOSMFJobManager) getSpoolFiles(ctx context.Context, jobName, jobID string) ([]SpoolFile, error) {
+	session, err := jm.getSession()
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
-
-	// Parse response
+	
+	// z/OSMF format: /restjobs/jobs/{jobname}/{jobid}/files
 	var spoolFiles []SpoolFile
-	if err := json.NewDecoder(resp.Body).Decode(&spoolFiles); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	path := fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + JobFilesEndpoint
+	if err := jm.getJSON(ctx, session, path, &spoolFiles); err != nil {
+		return nil, err
 	}
 
 	return spoolFiles, nil
@@ -483,37 +504,59 @@ func (jm *ZOSMFJobManager) GetSpoolFiles(jobName, jobID string) ([]SpoolFile, er
 
 // GetSpoolFileContent retrieves the content of a specific spool file
 func (jm *ZOSMFJobManager) GetSpoolFileContent(jobName, jobID string, spoolID int) (string, error) {
-	session := jm.session.(*profile.Session)
-	
-	// Build URL using the correct z/OSMF format: /restjobs/jobs/{jobname}/{jobid}/files/{id}/records
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + fmt.Sprintf(JobFilesByIDEndpoint, strconv.Itoa(spoolID))
+	return jm.GetSpoolFileContentWithOptions(jobName, jobID, spoolID, nil)
+}
+
+// GetSpoolFileContentWithOptions retrieves spool file content, optionally
+// limited to a record range and converted from a specific encoding
+func (jm *ZOSMFJobManager) GetSpoolFileContentWithOptions(jobName, jobID string, spoolID int, opts *SpoolContentOptions) (string, error) {
+	return jm.getSpoolFileContent(context.Background(), jobName, jobID, spoolID, opts)
+}
 
-	// Create request
-	req, err := http.NewRequest("GET", apiURL, nil)
+// getSpoolFileContent is GetSpoolFileContentWithOptions bounded by ctx
+func (jm *ZOSMFJobManager) getSpoolFileContent(ctx context.Context, jobName, jobID string, spoolID int, opts *SpoolContentOptions) (string, error) {
+	session, err := jm.getSession()
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", err
+	}
+	
+	// z/OSMF format: /restjobs/jobs/{jobname}/{jobid}/files/{id}/records
+	path := fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)) + fmt.Sprintf(JobFilesByIDEndpoint, strconv.Itoa(spoolID))
+	params := url.Values{}
+	if opts != nil {
+		switch opts.Mode {
+		case "", SpoolModeText:
+		case SpoolModeBinary, SpoolModeRecord:
+			// Raw modes skip codepage conversion, so an encoding means nothing
+			if opts.Encoding != "" {
+				return "", fmt.Errorf("encoding %s cannot be used with %s mode", opts.Encoding, opts.Mode)
+			}
+			params.Set("mode", string(opts.Mode))
+		default:
+			return "", fmt.Errorf("invalid spool mode: %s", opts.Mode)
+		}
+		if opts.Encoding != "" {
+			params.Set("fileEncoding", opts.Encoding)
+		}
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	headers := map[string]string{}
+	if opts != nil && opts.RecordCount > 0 {
+		// z/OSMF range is "start,count" with zero-based start
+		headers["X-IBM-Record-Range"] = fmt.Sprintf("%d,%d", opts.StartRecord, opts.RecordCount)
 	}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	resp, err := session.DoRawWithHeaders(ctx, "GET", path, nil, headers)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := session.ReadBody(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
@@ -543,43 +586,173 @@ func (jm *ZOSMFJobManager) GetSpoolFileContentByCorrelator(correlator string, sp
 
 
 
-// PurgeJob purges a job (removes it from the system)
+// modernPurgeMinVersion is the first zosmf_version that accepts the bodied purge
+const modernPurgeMinVersion = 25
+
+// PurgeJob purges a job (removes it from the system). Servers that support
+// it get the bodied purge request; older ones get the bare PUT .../purge.
 func (jm *ZOSMFJobManager) PurgeJob(correlator string) error {
-	session := jm.session.(*profile.Session)
-	
-	// Build URL
-	apiURL := session.GetBaseURL() + fmt.Sprintf(JobByCorrelatorEndpoint, url.PathEscape(correlator)) + PurgeEndpoint
+	if jm.supportsModernPurge() {
+		_, err := jm.PurgeJobWithFeedback(correlator)
+		return err
+	}
+	return jm.purgeJobLegacy(correlator)
+}
 
-	// Create request
-	req, err := http.NewRequest("PUT", apiURL, nil)
+// PurgeJobWithFeedback purges a job with the {"request":"purge"} body and
+// returns z/OSMF's synchronous feedback. Accepts either jobname:jobid or a
+// z/OSMF job correlator.
+func (jm *ZOSMFJobManager) PurgeJobWithFeedback(correlator string) (*JobFeedback, error) {
+	session, err := jm.getSession()
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	// Add headers
-	for key, value := range session.GetHeaders() {
-		req.Header.Set(key, value)
+	path, err := jobPath(correlator)
+	if err != nil {
+		return nil, err
 	}
 
-	// Make request
-	resp, err := session.GetHTTPClient().Do(req)
+	// Create request body
+	jsonBody, err := json.Marshal(map[string]string{"request": "purge", "version": "2.0"})
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp, err := session.DoRaw(context.Background(), "PUT", path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, purgeError(err)
 	}
 	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// Parse feedback
+	data, err := session.ReadBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	var feedback JobFeedback
+	if err := profile.DecodeJSON(data, &feedback, jm.StrictJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// A 200 can still carry a failure in the feedback status
+	if feedback.Status != "" && feedback.Status != "0" {
+		return &feedback, purgeError(session.NewAPIError(resp.StatusCode, []byte(feedback.Message)))
+	}
+
+	return &feedback, nil
+}
+
+// supportsModernPurge asks z/OSMF for its version until it gets an answer,
+// then keeps it for the manager. While it can't tell, e.g. /info failed, the
+// server is treated as an older one.
+func (jm *ZOSMFJobManager) supportsModernPurge() bool {
+	jm.capabilityMu.Lock()
+	defer jm.capabilityMu.Unlock()
+	if jm.capabilityDetected {
+		return jm.modernPurge
+	}
+
+	session := jm.session
+	if session == nil {
+		return false
+	}
+	info, err := session.GetZOSMFInfo()
+	if err != nil {
+		return false
+	}
+	version, err := strconv.Atoi(info.ZOSMFVersion)
+	jm.modernPurge = err == nil && version >= modernPurgeMinVersion
+	jm.capabilityDetected = true
+	return jm.modernPurge
+}
+
+// purgeJobLegacy purges a job with the bare PUT .../purge request
+func (jm *ZOSMFJobManager) purgeJobLegacy(correlator string) error {
+	session, err := jm.getSession()
+	if err != nil {
+		return err
+	}
+	
+	path, err := jobPath(correlator)
+	if err != nil {
+		return err
+	}
+	return purgeError(session.DoJSON(context.Background(), "PUT", path+PurgeEndpoint, nil, nil))
+}
+
+// activeWordPattern finds "active" as a word, capturing a negating "not" or
+// "in" prefix so "job is active" can be told apart from "job is not active"
+var activeWordPattern = regexp.MustCompile(`(?i)\b(not\s+|in)?active\b`)
+
+// purgeError maps a failed purge's APIError onto the job error values
+func purgeError(err error) error {
+	var apiErr *profile.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", ErrJobNotFound, err)
+	}
+	if reportsJobActive(apiErr.Body) {
+		return fmt.Errorf("%w: %w", ErrJobActive, err)
+	}
+	return err
+}
+
+// jobPath is the path of a job given as jobname:jobid or a z/OSMF correlator
+func jobPath(correlator string) (string, error) {
+	if !strings.Contains(correlator, ":") {
+		return fmt.Sprintf(JobByCorrelatorEndpoint, url.PathEscape(correlator)), nil
+	}
+	jobName, jobID, err := parseCorrelator(correlator)
+	if err != nil {
+		return "", fmt.Errorf("invalid correlator format: %w", err)
+	}
+	return fmt.Sprintf(JobByNameIDEndpoint, url.PathEscape(jobName), url.PathEscape(jobID)), nil
+}
+
+// reportsJobActive reports whether a purge failure message says the job is
+// still running, ignoring "inactive" and "not active"
+func reportsJobActive(message string) bool {
+	for _, match := range activeWordPattern.FindAllStringSubmatch(message, -1) {
+		if match[1] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// reportsJobNotActive reports whether a failure message says the job is no
+// longer running, e.g. "job is not active"
+func reportsJobNotActive(message string) bool {
+	for _, match := range activeWordPattern.FindAllStringSubmatch(message, -1) {
+		if match[1] != "" {
+			return true
+		}
 	}
+	return false
+}
 
+// getJSON issues a GET for path under the base URL and decodes the reply
+// into v, honoring StrictJSON
+func (jm *ZOSMFJobManager) getJSON(ctx context.Context, session *profile.Session, path string, v interface{}) error {
+	var raw json.RawMessage
+	if err := session.DoJSON(ctx, "GET", path, nil, &raw); err != nil {
+		return err
+	}
+	if err := profile.DecodeJSON(raw, v, jm.StrictJSON); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
 	return nil
 }
 
 // CloseJobManager closes the job manager and its underlying HTTP connections
 func (jm *ZOSMFJobManager) CloseJobManager() error {
-	session := jm.session.(*profile.Session)
+	session := jm.session
+	if session == nil {
+		return nil
+	}
 	
 	// Close idle connections in the HTTP client
 	if client := session.GetHTTPClient(); client != nil {
@@ -1,7 +1,23 @@
 package jobs
 
 import (
+	"errors"
+	"sync"
 	"time"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// Job errors callers can match with errors.Is
+var (
+	// ErrJobNotFound is returned when z/OSMF has no record of the job (or it was purged)
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobActive is returned when an operation needs the job to have finished executing
+	ErrJobActive = errors.New("job is active")
+	// ErrJCLError is returned when JES rejects a submitted job's JCL
+	ErrJCLError = errors.New("JCL error")
+	// ErrWaitCanceled is delivered when an asynchronous wait is cancelled
+	ErrWaitCanceled = errors.New("wait canceled")
 )
 
 // Job represents a z/OS job
@@ -13,16 +29,31 @@ type Job struct {
 	Subsystem   string            `json:"subsystem,omitempty"`
 	Type        string            `json:"type,omitempty"`
 	Class       string            `json:"class,omitempty"`
+	Phase       int               `json:"phase,omitempty"` // JES phase number z/OSMF reports alongside phase-name
 	PhaseName   string            `json:"phase-name,omitempty"`
 	PhaseNumber int               `json:"phase-number,omitempty"`
 	RetCode     string            `json:"retcode,omitempty"`
 	URL         string            `json:"url,omitempty"`
 	FilesURL    string            `json:"files-url,omitempty"`
 	JobCorrelator string          `json:"job-correlator,omitempty"`
+	ReasonNotRunning string       `json:"reason-not-running,omitempty"` // Why an input job isn't running yet
 	ExecutionClass string         `json:"execution-class,omitempty"`
 	ExecutionMode string          `json:"execution-mode,omitempty"`
 	JobInfo     *JobInfo          `json:"job-info,omitempty"`
 	SpoolFiles  []SpoolFile       `json:"spool-files,omitempty"`
+
+	// Execution data, only returned when the listing asks for exec-data
+	ExecSystem    string `json:"exec-system,omitempty"`
+	ExecMember    string `json:"exec-member,omitempty"`
+	ExecSubmitted string `json:"exec-submitted,omitempty"`
+	ExecStarted   string `json:"exec-started,omitempty"`
+	ExecEnded     string `json:"exec-ended,omitempty"`
+
+	// The exec-data timestamps parsed by ListJobs; zero when absent or
+	// in a format the SDK doesn't recognise
+	Submitted time.Time `json:"-"`
+	Started   time.Time `json:"-"`
+	Ended     time.Time `json:"-"`
 }
 
 // JobInfo contains detailed information about a job
@@ -53,15 +84,32 @@ type SpoolFile struct {
 	StepName    string `json:"stepname,omitempty"`
 	ProcStep    string `json:"procstep,omitempty"`
 	Class       string `json:"class,omitempty"`
-	Records     int    `json:"records,omitempty"`
-	Bytes       int    `json:"bytes,omitempty"`
+	Records     int    `json:"record-count,omitempty"`
+	Bytes       int    `json:"byte-count,omitempty"`
+	RecFM       string `json:"recfm,omitempty"`
+	LRECL       int    `json:"lrecl,omitempty"`
+	JobName     string `json:"jobname,omitempty"`
+	JobID       string `json:"jobid,omitempty"`
+	Subsystem   string `json:"subsystem,omitempty"`
 	URL         string `json:"url,omitempty"`
+	RecordsURL  string `json:"records-url,omitempty"`
 	ContentURL  string `json:"content-url,omitempty"`
 }
 
+// SpoolSection is one spool file's content from GetJobOutputConcurrent
+type SpoolSection struct {
+	SpoolFile SpoolFile
+	Content   string
+	Err       error // Set when this file couldn't be fetched
+}
+
 // JobList represents a list of jobs
 type JobList struct {
 	Jobs []Job `json:"jobs"`
+
+	// Truncated is set when the listing returned as many jobs as the
+	// max-jobs limit allows, so more may exist. z/OSMF doesn't report this.
+	Truncated bool `json:"-"`
 }
 
 // SubmitJobRequest represents a job submission request
@@ -72,15 +120,31 @@ type SubmitJobRequest struct {
 	Directory string `json:"directory,omitempty"`
 	Extension string `json:"extension,omitempty"`
 	Volume string `json:"volume,omitempty"`
+	Symbols map[string]string `json:"symbols,omitempty"` // JCL symbols, sent as X-IBM-JCL-Symbol-<name>
 }
 
+// IntrdrOptions describes the internal reader for JCL submitted as text
+type IntrdrOptions struct {
+	Class string // Internal reader class, default A
+	RecFm string // Record format, F or V; default F
+	LRecl int    // Logical record length, default 80
+}
+
+// Internal reader defaults for SubmitJCL
+const (
+	DefaultIntrdrClass = "A"
+	DefaultIntrdrRecFm = "F"
+	DefaultIntrdrLRecl = 80
+)
+
 // SubmitJobResponse represents a job submission response
 type SubmitJobResponse struct {
-	JobID   string `json:"jobid"`
-	JobName string `json:"jobname"`
-	Owner   string `json:"owner"`
-	Status  string `json:"status"`
-	URL     string `json:"url,omitempty"`
+	JobID         string `json:"jobid"`
+	JobName       string `json:"jobname"`
+	Owner         string `json:"owner"`
+	Status        string `json:"status"`
+	URL           string `json:"url,omitempty"`
+	JobCorrelator string `json:"job-correlator,omitempty"`
 }
 
 // JobFilter represents filters for job queries
@@ -92,6 +156,100 @@ type JobFilter struct {
 	JobName     string `json:"jobname,omitempty"`
 	Status      string `json:"status,omitempty"`
 	UserCorrelator string `json:"user-correlator,omitempty"`
+	ActiveOnly  bool   `json:"-"` // Only list active jobs (status=active)
+	ExecData    bool   `json:"-"` // Ask for exec-data so jobs carry phase and execution details
+
+	// Keep only jobs submitted in this window, checked client-side against
+	// the exec-data submission time. SubmittedAfter is inclusive and
+	// SubmittedBefore exclusive; either may be zero. Setting one implies
+	// ExecData, and jobs without a submission time are dropped.
+	SubmittedAfter  time.Time `json:"-"`
+	SubmittedBefore time.Time `json:"-"`
+}
+
+// DDStatement describes a JCL DD statement for the JCL builders.
+// Disp, Space and DCB take the operand value without the keyword,
+// e.g. Disp "(NEW,CATLG,DELETE)" or Space "(TRK,(1,1))".
+type DDStatement struct {
+	Name           string        // DD name (blank for concatenated entries)
+	DSN            string        // Dataset name
+	Disp           string        // DISP operand
+	Unit           string        // UNIT operand
+	Space          string        // SPACE operand
+	DCB            string        // DCB operand
+	Sysout         string        // SYSOUT class, e.g. "*" or "A"
+	Dummy          bool          // Emit DD DUMMY
+	InStream       []string      // In-stream data lines (DD *)
+	Concatenations []DDStatement // Datasets concatenated after this one
+}
+
+// JobResult is the outcome of a finished job
+type JobResult struct {
+	JobID         string `json:"jobid"`
+	JobName       string `json:"jobname"`
+	Status        string `json:"status"`        // Final job status, normally OUTPUT
+	RetCode       string `json:"retcode"`       // e.g. "CC 0000", "JCL ERROR", "ABEND S322"
+	ConditionCode int    `json:"conditionCode"` // Numeric code from "CC nnnn", or -1 when there is none
+	Succeeded     bool   `json:"succeeded"`     // True only for CC 0000
+}
+
+// WaitResult is delivered by WaitForJobCompletionAsync
+type WaitResult struct {
+	Result *JobResult // Set when the job completed
+	Err    error      // Polling failure, or ErrWaitCanceled
+}
+
+// SpoolContentOptions narrows a spool file read
+type SpoolContentOptions struct {
+	StartRecord int       // Zero-based first record
+	RecordCount int       // Records to read; 0 reads to the end
+	Encoding    string    // Source codepage, e.g. IBM-1047; text mode only
+	Mode        SpoolMode // How records are returned, defaults to text
+}
+
+// SpoolMode selects how z/OSMF returns spool records
+type SpoolMode string
+
+const (
+	SpoolModeText   SpoolMode = "text"   // Converted to the client codepage, one line per record
+	SpoolModeBinary SpoolMode = "binary" // Raw bytes, no conversion
+	SpoolModeRecord SpoolMode = "record" // Raw records, each prefixed with its 4-byte length
+)
+
+// SpoolRecords is a page of spool file records
+type SpoolRecords struct {
+	Records     []string // Records read, without line terminators
+	StartRecord int      // Zero-based index of the first record
+	More        bool     // Whether records remain after this page
+}
+
+// JobFeedback is the synchronous result z/OSMF returns for a job modification
+type JobFeedback struct {
+	JobID         string `json:"jobid"`
+	JobName       string `json:"jobname"`
+	OriginalJobID string `json:"original-jobid,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+	Member        string `json:"member,omitempty"`
+	Sysname       string `json:"sysname,omitempty"`
+	JobCorrelator string `json:"job-correlator,omitempty"`
+	Status        string `json:"status"`            // "0" on success
+	Message       string `json:"message,omitempty"` // Reason when Status is nonzero
+}
+
+// JobEventType identifies the kind of change a JobWatcher saw
+type JobEventType string
+
+const (
+	JobAdded         JobEventType = "added"          // Job appeared in the listing
+	JobStatusChanged JobEventType = "status-changed" // Status, phase or retcode changed
+	JobRemoved       JobEventType = "removed"        // Job no longer listed (purged)
+)
+
+// JobEvent is a single change emitted by a JobWatcher
+type JobEvent struct {
+	Type           JobEventType
+	Job            Job
+	PreviousStatus string // Set for JobStatusChanged
 }
 
 // JobManager interface for job management operations
@@ -102,16 +260,29 @@ type JobManager interface {
 	GetJobStatus(jobID string) (string, error)
 	GetJobByNameID(jobName, jobID string) (*Job, error)
 	GetJobByCorrelator(correlator string) (*Job, error)
+	GetJobByID(jobID string) (*Job, error)
 	SubmitJob(request *SubmitJobRequest) (*SubmitJobResponse, error)
 	CancelJob(jobID string) error
+	HoldJob(jobID string) error
+	ReleaseJob(jobID string) error
 	DeleteJob(jobID string) error
 	GetSpoolFiles(jobID string) ([]SpoolFile, error)
 	GetSpoolFileContent(jobID string, spoolID int) (string, error)
 	PurgeJob(jobID string) error
+	PurgeJobWithFeedback(jobID string) (*JobFeedback, error)
 	CloseJobManager() error
 }
 
 // ZOSMFJobManager implements JobManager for ZOSMF
 type ZOSMFJobManager struct {
-	session interface{} // Will be *profile.Session
+	session *profile.Session
+
+	// StrictJSON decodes responses in profile.DecodeJSON's strict mode
+	StrictJSON bool
+
+	// Server capabilities, detected on first use. A failed detection isn't
+	// kept, so the next call asks again.
+	capabilityMu       sync.Mutex
+	capabilityDetected bool
+	modernPurge        bool
 }
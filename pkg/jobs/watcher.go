@@ -0,0 +1,187 @@
+package jobs
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobWatcher polls ListJobs and reports jobs being added, changing status
+// and being removed. Each poll is diffed against the previous one, so
+// several changes between polls coalesce into a single event per job.
+type JobWatcher struct {
+	jm       *ZOSMFJobManager
+	filter   *JobFilter
+	interval time.Duration
+
+	known  map[string]Job
+	events chan JobEvent
+	errors chan error
+
+	mu       sync.Mutex
+	started  bool // Start launched the background loop
+	stopped  bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewJobWatcher creates a watcher for the jobs matching filter.
+// Call Start to begin polling or Poll to drive it manually.
+func (jm *ZOSMFJobManager) NewJobWatcher(filter *JobFilter, interval time.Duration) *JobWatcher {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &JobWatcher{
+		jm:       jm,
+		filter:   filter,
+		interval: interval,
+		known:    make(map[string]Job),
+		events:   make(chan JobEvent, 64),
+		errors:   make(chan error, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed after Stop.
+func (w *JobWatcher) Events() <-chan JobEvent {
+	return w.events
+}
+
+// Errors returns poll failures. Only the most recent unread error is kept.
+func (w *JobWatcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Start begins polling in the background. It does nothing if the watcher
+// is already running or has been stopped.
+func (w *JobWatcher) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started || w.stopped {
+		return
+	}
+	w.started = true
+	go w.run()
+}
+
+// Stop ends polling and waits for the background loop to exit, if Start
+// launched one. It is safe to call more than once.
+func (w *JobWatcher) Stop() {
+	w.mu.Lock()
+	started := w.started
+	w.stopped = true
+	w.mu.Unlock()
+
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		// Without a loop nothing sends on events, so close it here
+		if !started {
+			close(w.events)
+		}
+	})
+	if started {
+		<-w.done
+	}
+}
+
+// run polls on the interval until stopped
+func (w *JobWatcher) run() {
+	defer close(w.done)
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.Poll()
+		if err != nil {
+			w.reportError(err)
+		}
+		for _, event := range events {
+			select {
+			case w.events <- event:
+			case <-w.stop:
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// reportError keeps the latest error without blocking the poll loop
+func (w *JobWatcher) reportError(err error) {
+	select {
+	case w.errors <- err:
+	default:
+		select {
+		case <-w.errors:
+		default:
+		}
+		select {
+		case w.errors <- err:
+		default:
+		}
+	}
+}
+
+// Poll lists jobs once and returns the changes since the previous poll.
+// A failed listing leaves the known state untouched. Don't call Poll
+// while the background loop started by Start is running.
+func (w *JobWatcher) Poll() ([]JobEvent, error) {
+	jobList, err := w.jm.ListJobs(w.filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Duplicate entries in one listing collapse to a single job
+	current := make(map[string]Job, len(jobList.Jobs))
+	for _, job := range jobList.Jobs {
+		current[jobKey(job)] = job
+	}
+
+	var events []JobEvent
+	for key, job := range current {
+		previous, seen := w.known[key]
+		switch {
+		case !seen:
+			events = append(events, JobEvent{Type: JobAdded, Job: job})
+		case jobChanged(previous, job):
+			events = append(events, JobEvent{Type: JobStatusChanged, Job: job, PreviousStatus: previous.Status})
+		}
+	}
+	for key, job := range w.known {
+		if _, still := current[key]; !still {
+			events = append(events, JobEvent{Type: JobRemoved, Job: job})
+		}
+	}
+	w.known = current
+
+	// Keep event order stable between runs
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Job.JobID != events[j].Job.JobID {
+			return events[i].Job.JobID < events[j].Job.JobID
+		}
+		return events[i].Type < events[j].Type
+	})
+
+	return events, nil
+}
+
+// jobKey identifies a job across polls
+func jobKey(job Job) string {
+	return job.JobName + ":" + job.JobID
+}
+
+// jobChanged reports whether anything a watcher cares about differs
+func jobChanged(previous, current Job) bool {
+	return previous.Status != current.Status ||
+		previous.RetCode != current.RetCode ||
+		previous.PhaseName != current.PhaseName
+}
@@ -2,9 +2,23 @@ package profile
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
+// detectedProtocols caches the protocol DetectSession found per host:port
+var (
+	detectedProtocols   = make(map[string]string)
+	detectedProtocolsMu sync.Mutex
+)
+
+// detectProbeTimeout bounds each protocol probe made by DetectSession
+const detectProbeTimeout = 10 * time.Second
+
 // CreateZOSMFProfile creates a ZOSMF profile with the given parameters
 func CreateZOSMFProfile(name, host string, port int, user, password string) *ZOSMFProfile {
 	return &ZOSMFProfile{
@@ -33,6 +47,52 @@ func CreateZOSMFProfileWithOptions(name, host string, port int, user, password s
 	}
 }
 
+// LoadProfileFromEnv builds a profile from <PREFIX>_HOST, _PORT, _USER,
+// _PASSWORD, _TOKEN, _REJECT_UNAUTHORIZED, _BASE_PATH and _PROTOCOL, for
+// CI jobs that keep credentials in the environment. HOST and either
+// USER/PASSWORD or TOKEN are required; PORT defaults to 443 and
+// REJECT_UNAUTHORIZED to true.
+func LoadProfileFromEnv(prefix string) (*ZOSMFProfile, error) {
+	env := func(name string) string {
+		return strings.TrimSpace(os.Getenv(prefix + "_" + name))
+	}
+
+	profile := &ZOSMFProfile{
+		Name:               strings.ToLower(prefix),
+		Host:               env("HOST"),
+		Port:               443,
+		User:               env("USER"),
+		Password:           env("PASSWORD"),
+		TokenValue:         env("TOKEN"),
+		RejectUnauthorized: true,
+		BasePath:           env("BASE_PATH"),
+		Protocol:           "https",
+	}
+
+	if port := env("PORT"); port != "" {
+		value, err := strconv.Atoi(port)
+		if err != nil || value < 1 || value > 65535 {
+			return nil, fmt.Errorf("%s_PORT must be a port number, not %q", prefix, port)
+		}
+		profile.Port = value
+	}
+	if reject := env("REJECT_UNAUTHORIZED"); reject != "" {
+		value, err := strconv.ParseBool(reject)
+		if err != nil {
+			return nil, fmt.Errorf("%s_REJECT_UNAUTHORIZED must be true or false, not %q", prefix, reject)
+		}
+		profile.RejectUnauthorized = value
+	}
+	if protocol := env("PROTOCOL"); protocol != "" {
+		profile.Protocol = strings.ToLower(protocol)
+	}
+
+	if err := ValidateProfile(profile); err != nil {
+		return nil, fmt.Errorf("invalid profile from %s_* environment variables: %w", prefix, err)
+	}
+	return profile, nil
+}
+
 // CreateSessionDirect creates a session with connection details
 func CreateSessionDirect(host string, port int, user, password string) (*Session, error) {
 	profile := &ZOSMFProfile{
@@ -61,16 +121,90 @@ func CreateSessionDirectWithOptions(host string, port int, user, password string
 	return profile.NewSession()
 }
 
+// DetectSession creates a session for a host whose protocol isn't known,
+// probing /zosmf/info over https and then http. Any HTTP response, even an
+// auth failure, counts as the protocol working, as does an https certificate
+// that fails verification. Probes honour HTTP(S)_PROXY like the session's
+// own requests. The result is cached per host and port for later calls.
+func DetectSession(host string, port int, user, password string) (*Session, error) {
+	profile := &ZOSMFProfile{
+		Host:               host,
+		Port:               port,
+		User:               user,
+		Password:           password,
+		RejectUnauthorized: true,
+	}
+
+	key := fmt.Sprintf("%s:%d", host, port)
+	detectedProtocolsMu.Lock()
+	protocol, cached := detectedProtocols[key]
+	detectedProtocolsMu.Unlock()
+
+	if !cached {
+		var lastErr error
+		for _, candidate := range []string{"https", "http"} {
+			if lastErr = probeProtocol(profile, candidate); lastErr == nil {
+				protocol = candidate
+				break
+			}
+		}
+		if protocol == "" {
+			return nil, fmt.Errorf("z/OSMF not reachable over https or http at %s: %w", key, lastErr)
+		}
+
+		detectedProtocolsMu.Lock()
+		detectedProtocols[key] = protocol
+		detectedProtocolsMu.Unlock()
+	}
+
+	profile.Protocol = protocol
+	return profile.NewSession()
+}
+
+// probeProtocol checks whether z/OSMF answers on the given protocol at the
+// profile's host and port, going through the proxy its session would use
+func probeProtocol(profile *ZOSMFProfile, protocol string) error {
+	proxy, err := profile.proxyFunc()
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: proxy},
+		Timeout:   detectProbeTimeout,
+	}
+	defer client.CloseIdleConnections()
+
+	probeURL := fmt.Sprintf("%s://%s/zosmf/info", protocol, profile.Host)
+	if profile.Port != 0 {
+		probeURL = fmt.Sprintf("%s://%s:%d/zosmf/info", protocol, profile.Host, profile.Port)
+	}
+
+	resp, err := client.Get(probeURL)
+	if err != nil {
+		// A certificate z/OSMF can't prove (usually self-signed) still means
+		// https is what's listening; falling back to http would be wrong
+		if protocol == "https" && isCertificateError(err) {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // ValidateProfile validates that a ZOSMF profile has all required fields
 func ValidateProfile(profile *ZOSMFProfile) error {
 	if profile.Host == "" {
 		return fmt.Errorf("host is required")
 	}
-	if profile.User == "" {
-		return fmt.Errorf("user is required")
-	}
-	if profile.Password == "" {
-		return fmt.Errorf("password is required")
+	// A token stands in for user and password
+	if profile.TokenValue == "" {
+		if profile.User == "" {
+			return fmt.Errorf("user is required")
+		}
+		if profile.Password == "" {
+			return fmt.Errorf("password is required")
+		}
 	}
 	if profile.Port <= 0 {
 		return fmt.Errorf("port must be greater than 0")
@@ -80,7 +214,7 @@ func ValidateProfile(profile *ZOSMFProfile) error {
 
 // CloneProfile creates a copy of a ZOSMF profile
 func CloneProfile(profile *ZOSMFProfile) *ZOSMFProfile {
-	return &ZOSMFProfile{
+	clone := &ZOSMFProfile{
 		Name:               profile.Name,
 		Host:               profile.Host,
 		Port:               profile.Port,
@@ -93,7 +227,17 @@ func CloneProfile(profile *ZOSMFProfile) *ZOSMFProfile {
 		ResponseTimeout:    profile.ResponseTimeout,
 		CertFile:           profile.CertFile,
 		CertKeyFile:        profile.CertKeyFile,
+		ClientID:           profile.ClientID,
+		ClientIDHeader:     profile.ClientIDHeader,
+		TokenType:          profile.TokenType,
+		TokenValue:         profile.TokenValue,
+		ProxyURL:           profile.ProxyURL,
+	}
+	if profile.Transport != nil {
+		transport := *profile.Transport
+		clone.Transport = &transport
 	}
+	return clone
 }
 
 // WriteTestConfig writes a test configuration to a file
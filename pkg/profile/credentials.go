@@ -0,0 +1,174 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// CredentialService is the service name secrets are stored under in a
+// CredentialStore
+const CredentialService = "zowe-client-go-sdk"
+
+// ErrCredentialNotFound is returned by a CredentialStore that has no value
+// for the requested account
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore keeps profile secrets outside the config file.
+// KeyringCredentialStore is backed by the OS keyring.
+type CredentialStore interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// KeyringCredentialStore is a CredentialStore in the OS keyring: the macOS
+// Keychain, the Windows Credential Manager, or the Secret Service (e.g.
+// GNOME Keyring) over D-Bus on Linux
+type KeyringCredentialStore struct{}
+
+// NewKeyringCredentialStore creates a credential store backed by the OS keyring
+func NewKeyringCredentialStore() *KeyringCredentialStore {
+	return &KeyringCredentialStore{}
+}
+
+// Get returns a stored secret or ErrCredentialNotFound
+func (KeyringCredentialStore) Get(service, account string) (string, error) {
+	secret, err := keyring.Get(service, account)
+	return secret, keyringError(err)
+}
+
+// Set stores a secret
+func (KeyringCredentialStore) Set(service, account, secret string) error {
+	return keyringError(keyring.Set(service, account, secret))
+}
+
+// Delete removes a secret, returning ErrCredentialNotFound when absent
+func (KeyringCredentialStore) Delete(service, account string) error {
+	return keyringError(keyring.Delete(service, account))
+}
+
+// keyringError maps the keyring's not-found error to ErrCredentialNotFound
+func keyringError(err error) error {
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrCredentialNotFound
+	}
+	return err
+}
+
+// MemoryCredentialStore is a CredentialStore held in memory, for tests and
+// short-lived tools
+type MemoryCredentialStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+// NewMemoryCredentialStore creates an empty in-memory credential store
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{secrets: make(map[string]string)}
+}
+
+// Get returns a stored secret or ErrCredentialNotFound
+func (m *MemoryCredentialStore) Get(service, account string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.secrets[service+"\x00"+account]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return secret, nil
+}
+
+// Set stores a secret
+func (m *MemoryCredentialStore) Set(service, account, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[service+"\x00"+account] = secret
+	return nil
+}
+
+// Delete removes a secret, returning ErrCredentialNotFound when absent
+func (m *MemoryCredentialStore) Delete(service, account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := service + "\x00" + account
+	if _, ok := m.secrets[key]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(m.secrets, key)
+	return nil
+}
+
+// credentialNameProperty records the profile name a saved profile's secrets
+// are stored under when it differs from the profile's path
+const credentialNameProperty = "credentialName"
+
+// credentialAccount is the store account for one secure property of a profile
+func credentialAccount(name, property string) string {
+	return name + "/" + property
+}
+
+// credentialName is the name a profile's secrets are stored under: the
+// recorded profile name, or else its path
+func credentialName(profilePath string, properties map[string]interface{}) string {
+	if name, ok := properties[credentialNameProperty].(string); ok && name != "" {
+		return name
+	}
+	return profilePath
+}
+
+// SetCredentialStore makes the manager keep passwords and tokens in store
+// rather than in the config file. Saved profiles list them under "secure";
+// loading reads them back from the store. Set UsePlaintext to write them to
+// the file anyway where no store is available.
+func (pm *ZOSMFProfileManager) SetCredentialStore(store CredentialStore) {
+	pm.credentialStore = store
+}
+
+// usesCredentialStore reports whether secrets should go to the store
+func (pm *ZOSMFProfileManager) usesCredentialStore() bool {
+	return pm.credentialStore != nil && !pm.UsePlaintext
+}
+
+// storeSecrets moves secret properties into the credential store under the
+// profile name and returns the property names to list under "secure"
+func (pm *ZOSMFProfileManager) storeSecrets(name string, properties map[string]interface{}) ([]string, error) {
+	var secure []string
+	for _, key := range []string{"password", "tokenValue"} {
+		value, ok := properties[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		if err := pm.credentialStore.Set(CredentialService, credentialAccount(name, key), value); err != nil {
+			return nil, fmt.Errorf("failed to store %s in credential store: %w", key, err)
+		}
+		delete(properties, key)
+		secure = append(secure, key)
+	}
+	return secure, nil
+}
+
+// lookupSecret resolves a secure property from the resolver by profile
+// path, then from the credential store by name. It returns "" when neither
+// has it.
+func (pm *ZOSMFProfileManager) lookupSecret(profilePath, name, property string) (string, error) {
+	if pm.secureResolver != nil {
+		value, err := pm.secureResolver(profilePath, property)
+		if err != nil {
+			return "", err
+		}
+		if value != "" {
+			return value, nil
+		}
+	}
+	if pm.credentialStore != nil {
+		value, err := pm.credentialStore.Get(CredentialService, credentialAccount(name, property))
+		if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+			return "", err
+		}
+		return value, nil
+	}
+	return "", nil
+}
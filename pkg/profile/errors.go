@@ -0,0 +1,104 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when z/OSMF answers with an unexpected HTTP status.
+// Use errors.As to inspect it, or the IsNotFound style helpers.
+type APIError struct {
+	StatusCode int      // HTTP status code
+	Category   int      // z/OSMF error category, when the body carried one
+	ReturnCode int      // z/OSMF rc
+	Reason     int      // z/OSMF reason code
+	Message    string   // z/OSMF message, or the raw body when it isn't JSON
+	Details    []string // z/OSMF details lines, when present
+	Body       string   // Raw response body
+}
+
+// zosmfErrorBody is the JSON error payload z/OSMF REST services return
+type zosmfErrorBody struct {
+	Category   int      `json:"category"`
+	ReturnCode int      `json:"rc"`
+	Reason     int      `json:"reason"`
+	Message    string   `json:"message"`
+	Details    []string `json:"details"`
+}
+
+// NewAPIError builds an APIError from a failed response's status and body
+func NewAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	// Gateways and proxies answer with HTML; their title is the useful part
+	if title := htmlTitle(apiErr.Message); title != "" {
+		apiErr.Message = title
+		return apiErr
+	}
+
+	var payload zosmfErrorBody
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Category = payload.Category
+		apiErr.ReturnCode = payload.ReturnCode
+		apiErr.Reason = payload.Reason
+		apiErr.Details = payload.Details
+		if payload.Message != "" {
+			apiErr.Message = payload.Message
+		}
+	}
+	return apiErr
+}
+
+// htmlTitle returns the <title> of an HTML body, or "" when there is none
+func htmlTitle(body string) string {
+	lower := strings.ToLower(body)
+	start := strings.Index(lower, "<title>")
+	if start < 0 {
+		return ""
+	}
+	start += len("<title>")
+	end := strings.Index(lower[start:], "</title>")
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(body[start : start+end])
+}
+
+// Error keeps the long-standing "API request failed" wording
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsServerError reports whether err is an APIError with a 5xx status
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+// hasStatus reports whether err is an APIError with the given status
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,127 @@
+package profile
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ConnectionFailure says why Ping couldn't use the session
+type ConnectionFailure string
+
+const (
+	FailureNetwork ConnectionFailure = "network" // Host unreachable, refused, timed out
+	FailureTLS     ConnectionFailure = "tls"     // Handshake or certificate verification failed
+	FailureAuth    ConnectionFailure = "auth"    // z/OSMF rejected the credentials (401)
+	FailureServer  ConnectionFailure = "server"  // Any other non-2xx status
+)
+
+// ConnectionError is returned by Ping. Err is the underlying error; for
+// FailureAuth and FailureServer it is the *APIError.
+type ConnectionError struct {
+	Kind ConnectionFailure
+	Err  error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("z/OSMF connection check failed (%s): %v", e.Kind, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ZOSMFInfo is what z/OSMF reports about itself at /info, for feature
+// gating on the server version or on a plugin being active
+type ZOSMFInfo struct {
+	ZOSMFVersion     string        `json:"zosmf_version"`      // e.g. "27" for V2R4
+	ZOSMFFullVersion string        `json:"zosmf_full_version"` // e.g. "27.0"
+	ZOSMFHostname    string        `json:"zosmf_hostname"`
+	ZOSMFPort        string        `json:"zosmf_port"`
+	ZOSMFSAFRealm    string        `json:"zosmf_saf_realm"`
+	ZOSVersion       string        `json:"zos_version"`
+	APIVersion       string        `json:"api_version"`
+	Plugins          []ZOSMFPlugin `json:"plugins,omitempty"`
+}
+
+// HasPlugin reports whether a plugin with the given default name (e.g.
+// "Workflow") is installed and active
+func (i *ZOSMFInfo) HasPlugin(defaultName string) bool {
+	for _, plugin := range i.Plugins {
+		if plugin.DefaultName == defaultName && plugin.Status == "ACTIVE" {
+			return true
+		}
+	}
+	return false
+}
+
+// ZOSMFPlugin is one plugin listed by /info
+type ZOSMFPlugin struct {
+	Version     string `json:"pluginVersion"`
+	DefaultName string `json:"pluginDefaultName"`
+	Status      string `json:"pluginStatus"`
+}
+
+// GetZOSMFInfo reads the server's version, host and plugins from /info
+func (s *Session) GetZOSMFInfo() (*ZOSMFInfo, error) {
+	var info ZOSMFInfo
+	if err := s.DoJSON(context.Background(), http.MethodGet, "/info", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Ping checks that the session can reach z/OSMF and that its credentials
+// are accepted. /info doesn't require authentication, so the credentials
+// are checked against a listing of the user's own jobs (capped at one).
+// Failures come back as a *ConnectionError.
+func (s *Session) Ping(ctx context.Context) error {
+	for _, path := range []string{"/info", "/restjobs/jobs?max-jobs=1"} {
+		resp, err := s.DoRaw(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return classifyConnectionError(err)
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// classifyConnectionError wraps err in a ConnectionError of the right kind
+func classifyConnectionError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusUnauthorized {
+			return &ConnectionError{Kind: FailureAuth, Err: err}
+		}
+		return &ConnectionError{Kind: FailureServer, Err: err}
+	}
+	if isTLSError(err) {
+		return &ConnectionError{Kind: FailureTLS, Err: err}
+	}
+	return &ConnectionError{Kind: FailureNetwork, Err: err}
+}
+
+// isTLSError reports whether err came from the TLS handshake
+func isTLSError(err error) bool {
+	var header tls.RecordHeaderError
+	var alert tls.AlertError
+	return isCertificateError(err) ||
+		errors.As(err, &header) ||
+		errors.As(err, &alert)
+}
+
+// isCertificateError reports whether err is the server's certificate failing
+// verification, as opposed to the server not speaking TLS at all
+func isCertificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &invalid)
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 )
 
 // NewProfileManager creates a profile manager instance
@@ -23,32 +25,188 @@ func NewProfileManagerWithPath(configPath string) *ZOSMFProfileManager {
 	}
 }
 
-// GetZOSMFProfile gets a ZOSMF profile by name
+// NewProfileManagerAllowMissing creates a profile manager that treats a
+// missing config file as an empty configuration instead of an error.
+// An empty configPath uses the default Zowe config location.
+func NewProfileManagerAllowMissing(configPath string) *ZOSMFProfileManager {
+	if configPath == "" {
+		configPath = getZoweConfigPath()
+	}
+	return &ZOSMFProfileManager{
+		configPath:   configPath,
+		allowMissing: true,
+	}
+}
+
+// SetSecureValueResolver installs the lookup used for properties a profile
+// lists under "secure" that aren't inlined in the config. Without one such
+// profiles fail to load with ErrSecureValueMissing.
+func (pm *ZOSMFProfileManager) SetSecureValueResolver(resolver SecureValueResolver) {
+	pm.secureResolver = resolver
+}
+
+// GetZOSMFProfile gets a ZOSMF profile by name. Nested Zowe v2 profiles are
+// addressed by dotted path ("lpar1.zosmf") and inherit the properties of the
+// profiles they're nested in. "default" means the defaults.zosmf profile.
 func (pm *ZOSMFProfileManager) GetZOSMFProfile(name string) (*ZOSMFProfile, error) {
 	config, err := pm.loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Look for zosmf profile
-	zosmfProfile, exists := config.Profiles["zosmf"]
-	if !exists {
+	// Look for zosmf profiles
+	if len(zosmfProfilePaths(config)) == 0 {
 		return nil, fmt.Errorf("no zosmf profiles found in configuration")
 	}
 
+	path := resolveZOSMFPath(config, name)
+	chain := profileChain(config.Profiles, path)
+	if chain == nil {
+		return nil, fmt.Errorf("zosmf profile '%s' not found", name)
+	}
+	if chain[len(chain)-1].Type != "zosmf" {
+		return nil, fmt.Errorf("profile '%s' is not a zosmf profile", name)
+	}
+
+	properties, err := pm.mergeProperties(path, chain)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get base profile for inheritance
 	var baseProfile *BaseProfile
-	if baseProfileData, exists := config.Profiles["global_base"]; exists {
-		baseProfile = pm.parseBaseProfile(baseProfileData)
+	if basePath := baseProfilePath(config); basePath != "" {
+		baseProperties, err := pm.mergeProperties(basePath, profileChain(config.Profiles, basePath))
+		if err != nil {
+			return nil, err
+		}
+		baseProfile = pm.parseBaseProfile(ZoweProfile{Type: "base", Properties: baseProperties})
 	}
 
 	// Parse the ZOSMF profile
-	profile := pm.parseZOSMFProfile(name, zosmfProfile, baseProfile)
-	if profile == nil {
-		return nil, fmt.Errorf("zosmf profile '%s' not found", name)
+	return pm.parseZOSMFProfile(name, properties, baseProfile), nil
+}
+
+// baseProfilePath returns the path of the base profile zosmf profiles
+// inherit from: defaults.base, or the older global_base convention. It is
+// "" when the config has neither.
+func baseProfilePath(config *ZoweConfig) string {
+	if basePath := config.Defaults["base"]; basePath != "" {
+		if chain := profileChain(config.Profiles, basePath); chain != nil && chain[len(chain)-1].Type == "base" {
+			return basePath
+		}
+	}
+	if _, exists := config.Profiles["global_base"]; exists {
+		return "global_base"
+	}
+	return ""
+}
+
+// resolveZOSMFPath maps a profile name to its dotted path in the config.
+// Besides full paths it accepts "default" and, for configs that group
+// profiles under a "zosmf" entry, the name of a profile in that group.
+func resolveZOSMFPath(config *ZoweConfig, name string) string {
+	if name == "default" {
+		if defaultName := config.Defaults["zosmf"]; defaultName != "" && defaultName != "default" {
+			return defaultName
+		}
+		return "zosmf"
+	}
+	if profileChain(config.Profiles, name) == nil {
+		if profileChain(config.Profiles, "zosmf."+name) != nil {
+			return "zosmf." + name
+		}
+	}
+	return name
+}
+
+// profileChain returns the profiles along a dotted path, outermost first,
+// or nil when the path doesn't exist
+func profileChain(profiles map[string]ZoweProfile, path string) []ZoweProfile {
+	var chain []ZoweProfile
+	for _, segment := range strings.Split(path, ".") {
+		profile, exists := profiles[segment]
+		if !exists {
+			return nil
+		}
+		chain = append(chain, profile)
+		profiles = profile.Profiles
+	}
+	return chain
+}
+
+// mergeProperties flattens the properties along a profile chain, inner
+// profiles overriding outer ones, and fills in secure values that aren't
+// inlined from the resolver
+func (pm *ZOSMFProfileManager) mergeProperties(path string, chain []ZoweProfile) (map[string]interface{}, error) {
+	properties := inheritedProperties(chain)
+	secure := make(map[string]bool)
+	for _, profile := range chain {
+		for _, key := range profile.Secure {
+			secure[key] = true
+		}
 	}
 
-	return profile, nil
+	keys := make([]string, 0, len(secure))
+	for key := range secure {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, inlined := properties[key]; inlined {
+			continue
+		}
+		// Only chase values the SDK actually uses
+		if _, known := knownProperties[key]; !known {
+			continue
+		}
+		value, err := pm.lookupSecret(path, credentialName(path, properties), key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secure %s of profile '%s': %w", key, path, err)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%w: %s of profile '%s' is stored outside the config (set a SecureValueResolver or CredentialStore)", ErrSecureValueMissing, key, path)
+		}
+		properties[key] = value
+	}
+
+	return properties, nil
+}
+
+// inheritedProperties merges the properties along a profile chain,
+// inner profiles overriding outer ones
+func inheritedProperties(chain []ZoweProfile) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, profile := range chain {
+		for key, value := range profile.Properties {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+// zosmfProfilePaths returns the dotted paths of every zosmf profile, sorted
+func zosmfProfilePaths(config *ZoweConfig) []string {
+	var paths []string
+	walkProfiles("", config.Profiles, func(path string, profile ZoweProfile) {
+		if profile.Type == "zosmf" {
+			paths = append(paths, path)
+		}
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// walkProfiles calls fn for every profile, nested ones included, with its
+// dotted path
+func walkProfiles(prefix string, profiles map[string]ZoweProfile, fn func(path string, profile ZoweProfile)) {
+	for name, profile := range profiles {
+		path := prefix + name
+		fn(path, profile)
+		if len(profile.Profiles) > 0 {
+			walkProfiles(path+".", profile.Profiles, fn)
+		}
+	}
 }
 
 // parseBaseProfile parses the base profile from configuration
@@ -74,24 +232,25 @@ func (pm *ZOSMFProfileManager) parseBaseProfile(baseProfileData ZoweProfile) *Ba
 		if rejectUnauthorized, ok := properties["rejectUnauthorized"].(bool); ok {
 			baseProfile.RejectUnauthorized = rejectUnauthorized
 		}
+		if tokenType, ok := properties["tokenType"].(string); ok {
+			baseProfile.TokenType = tokenType
+		}
+		if tokenValue, ok := properties["tokenValue"].(string); ok {
+			baseProfile.TokenValue = tokenValue
+		}
+		if certFile, ok := properties["certFile"].(string); ok {
+			baseProfile.CertFile = certFile
+		}
+		if certKeyFile, ok := properties["certKeyFile"].(string); ok {
+			baseProfile.CertKeyFile = certKeyFile
+		}
 	}
 
 	return baseProfile
 }
 
-// parseZOSMFProfile parses a ZOSMF profile from configuration
-func (pm *ZOSMFProfileManager) parseZOSMFProfile(name string, zosmfProfile ZoweProfile, baseProfile *BaseProfile) *ZOSMFProfile {
-	// Check if this is the profile we're looking for
-	if zosmfProfile.Type != "zosmf" {
-		return nil
-	}
-
-	// In the new schema, we only have one ZOSMF profile, so we only return it if the name matches
-	// For now, we'll return the profile if the name is "zosmf" or if it's the default
-	if name != "zosmf" && name != "default" {
-		return nil
-	}
-
+// parseZOSMFProfile builds a ZOSMF profile from merged profile properties
+func (pm *ZOSMFProfileManager) parseZOSMFProfile(name string, properties map[string]interface{}, baseProfile *BaseProfile) *ZOSMFProfile {
 	profile := &ZOSMFProfile{
 		Name:               name,
 		RejectUnauthorized: true, // Default to true for security
@@ -113,10 +272,13 @@ func (pm *ZOSMFProfileManager) parseZOSMFProfile(name string, zosmfProfile ZoweP
 			profile.Password = baseProfile.Password
 		}
 		profile.RejectUnauthorized = baseProfile.RejectUnauthorized
+		profile.TokenType = baseProfile.TokenType
+		profile.TokenValue = baseProfile.TokenValue
+		profile.CertFile = baseProfile.CertFile
+		profile.CertKeyFile = baseProfile.CertKeyFile
 	}
 
 	// Apply ZOSMF profile properties (override base profile)
-	properties := zosmfProfile.Properties
 	if properties != nil {
 		if host, ok := properties["host"].(string); ok {
 			profile.Host = host
@@ -151,52 +313,58 @@ func (pm *ZOSMFProfileManager) parseZOSMFProfile(name string, zosmfProfile ZoweP
 		if certKeyFile, ok := properties["certKeyFile"].(string); ok {
 			profile.CertKeyFile = certKeyFile
 		}
+		if clientID, ok := properties["clientId"].(string); ok {
+			profile.ClientID = clientID
+		}
+		if clientIDHeader, ok := properties["clientIdHeader"].(string); ok {
+			profile.ClientIDHeader = clientIDHeader
+		}
+		if tokenType, ok := properties["tokenType"].(string); ok {
+			profile.TokenType = tokenType
+		}
+		if tokenValue, ok := properties["tokenValue"].(string); ok {
+			profile.TokenValue = tokenValue
+		}
+		if proxyURL, ok := properties["proxyUrl"].(string); ok {
+			profile.ProxyURL = proxyURL
+		}
 	}
 
 	return profile
 }
 
-// ListZOSMFProfiles returns a list of available ZOSMF profile names
+// ListZOSMFProfiles returns the names of the available ZOSMF profiles.
+// Nested profiles are listed by dotted path ("lpar1.zosmf").
 func (pm *ZOSMFProfileManager) ListZOSMFProfiles() ([]string, error) {
 	config, err := pm.loadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	zosmfProfile, exists := config.Profiles["zosmf"]
-	if !exists {
+	paths := zosmfProfilePaths(config)
+	if paths == nil {
 		return []string{}, nil
 	}
-
-	// For the new schema, we need to check if there are sub-profiles
-	// or if this is a single ZOSMF profile
-	if zosmfProfile.Type == "zosmf" {
-		// Single ZOSMF profile, return the default name
-		return []string{"zosmf"}, nil
-	}
-
-	// Check for sub-profiles
-	if zosmfProfile.Profiles != nil {
-		var profileNames []string
-		for name := range zosmfProfile.Profiles {
-			profileNames = append(profileNames, name)
-		}
-		return profileNames, nil
-	}
-
-	return []string{}, nil
+	return paths, nil
 }
 
 // SaveZOSMFProfile saves a ZOSMF profile to the configuration
 func (pm *ZOSMFProfileManager) SaveZOSMFProfile(profile *ZOSMFProfile) error {
-	config, err := pm.loadConfig()
-	if err != nil {
-		// If config doesn't exist, create a new one
-		config = &ZoweConfig{
-			Profiles: make(map[string]ZoweProfile),
-			Defaults: make(map[string]string),
+	// Start a new config only when there's none; never overwrite one that
+	// failed to load
+	config := &ZoweConfig{}
+	if _, err := os.Stat(pm.configPath); err == nil {
+		config, err = pm.loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
 		}
 	}
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]ZoweProfile)
+	}
+	if config.Defaults == nil {
+		config.Defaults = make(map[string]string)
+	}
 
 	// Ensure zosmf profile exists
 	if _, exists := config.Profiles["zosmf"]; !exists {
@@ -229,15 +397,75 @@ func (pm *ZOSMFProfileManager) SaveZOSMFProfile(profile *ZOSMFProfile) error {
 	if profile.CertKeyFile != "" {
 		properties["certKeyFile"] = profile.CertKeyFile
 	}
+	if profile.ClientID != "" {
+		properties["clientId"] = profile.ClientID
+	}
+	if profile.ClientIDHeader != "" {
+		properties["clientIdHeader"] = profile.ClientIDHeader
+	}
+	if profile.TokenType != "" {
+		properties["tokenType"] = profile.TokenType
+	}
+	if profile.TokenValue != "" {
+		properties["tokenValue"] = profile.TokenValue
+	}
+	if profile.ProxyURL != "" {
+		properties["proxyUrl"] = profile.ProxyURL
+	}
 
-	// Update the zosmf profile
+	// Keep secrets out of the file when there's a credential store, keyed
+	// by profile name so saving one doesn't overwrite another's
 	zosmfProfile := config.Profiles["zosmf"]
+	zosmfProfile.Secure = nil
+	if pm.usesCredentialStore() {
+		name := profile.Name
+		if name == "" {
+			name = "zosmf"
+		}
+		secure, err := pm.storeSecrets(name, properties)
+		if err != nil {
+			return err
+		}
+		zosmfProfile.Secure = secure
+		if name != "zosmf" {
+			properties[credentialNameProperty] = name
+		}
+	}
+
+	// Update the zosmf profile; other profiles and defaults are left alone
 	zosmfProfile.Properties = properties
 	config.Profiles["zosmf"] = zosmfProfile
+	if config.Defaults["zosmf"] == "" {
+		config.Defaults["zosmf"] = "zosmf"
+	}
 
 	return pm.saveConfig(config)
 }
 
+// SetDefaultZOSMFProfile makes name (a profile name or dotted path) the
+// defaults.zosmf profile
+func (pm *ZOSMFProfileManager) SetDefaultZOSMFProfile(name string) error {
+	config, err := pm.loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	path := resolveZOSMFPath(config, name)
+	chain := profileChain(config.Profiles, path)
+	if chain == nil {
+		return fmt.Errorf("zosmf profile '%s' not found", name)
+	}
+	if chain[len(chain)-1].Type != "zosmf" {
+		return fmt.Errorf("profile '%s' is not a zosmf profile", name)
+	}
+
+	if config.Defaults == nil {
+		config.Defaults = make(map[string]string)
+	}
+	config.Defaults["zosmf"] = path
+	return pm.saveConfig(config)
+}
+
 // DeleteZOSMFProfile deletes a ZOSMF profile from the configuration
 func (pm *ZOSMFProfileManager) DeleteZOSMFProfile(name string) error {
 	config, err := pm.loadConfig()
@@ -275,6 +503,12 @@ func (pm *ZOSMFProfileManager) GetDefaultZOSMFProfile() (*ZOSMFProfile, error) {
 func (pm *ZOSMFProfileManager) loadConfig() (*ZoweConfig, error) {
 	// Check if config file exists
 	if _, err := os.Stat(pm.configPath); os.IsNotExist(err) {
+		if pm.allowMissing {
+			return &ZoweConfig{
+				Profiles: make(map[string]ZoweProfile),
+				Defaults: make(map[string]string),
+			}, nil
+		}
 		return nil, fmt.Errorf("zowe config file not found at %s", pm.configPath)
 	}
 
@@ -292,11 +526,18 @@ func (pm *ZOSMFProfileManager) loadConfig() (*ZoweConfig, error) {
 	return &config, nil
 }
 
+// Permissions for the config file and a directory created for it
+const (
+	configFileMode os.FileMode = 0600
+	configDirMode  os.FileMode = 0700
+)
+
 // saveConfig saves the Zowe configuration to file
 func (pm *ZOSMFProfileManager) saveConfig(config *ZoweConfig) error {
-	// Ensure the directory exists
+	// Ensure the directory exists; only the owner may read it since the
+	// config can hold credentials
 	configDir := filepath.Dir(pm.configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(configDir, configDirMode); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
@@ -306,10 +547,16 @@ func (pm *ZOSMFProfileManager) saveConfig(config *ZoweConfig) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(pm.configPath, data, 0644); err != nil {
+	// Write to file. WriteFile keeps the mode of an existing file, so
+	// tighten it explicitly. Windows ignores the POSIX bits.
+	if err := os.WriteFile(pm.configPath, data, configFileMode); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(pm.configPath, configFileMode); err != nil {
+			return fmt.Errorf("failed to set config file permissions: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,34 @@
+//go:build unix
+
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveConfigPermissions(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), ".zowe")
+	configPath := filepath.Join(configDir, "zowe.config.json")
+	pm := NewProfileManagerWithPath(configPath)
+
+	require.NoError(t, pm.SaveZOSMFProfile(CreateZOSMFProfile("zosmf", "host.com", 443, "user", "pass")))
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	info, err = os.Stat(configDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// An existing world-readable file is tightened on the next save
+	require.NoError(t, os.Chmod(configPath, 0644))
+	require.NoError(t, pm.SetDefaultZOSMFProfile("zosmf"))
+	info, err = os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -0,0 +1,157 @@
+package profile
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RedactedText replaces secrets in redacted error and log text
+const RedactedText = "REDACTED"
+
+// credentialPatterns catch credentials in text even when the session doesn't
+// know their value, e.g. a token z/OSMF echoes back from another login
+var credentialPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(\b(?:proxy-)?authorization["']?\s*[:=]\s*["']?(?:basic|bearer)?\s*)[^\s"',;]+`),
+	regexp.MustCompile(`(?i)(\b(?:LtpaToken2|jwtToken|apimlAuthenticationToken)\s*=\s*)[^\s"',;]+`),
+	regexp.MustCompile(`(?i)(["']?\b(?:password|passwd|tokenValue)["']?\s*[:=]\s*["']?)[^\s"',;&]+`),
+}
+
+// Redact masks the given secrets and anything that looks like a password,
+// Authorization header or authentication cookie in text. A secret is only
+// masked where it stands as a whole word, so a short password such as "JOB1"
+// doesn't also mask "JOB10".
+func Redact(text string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			text = maskWord(text, secret)
+		}
+	}
+	for _, pattern := range credentialPatterns {
+		text = pattern.ReplaceAllString(text, "${1}"+RedactedText)
+	}
+	return text
+}
+
+// maskWord replaces each occurrence of secret in text that isn't part of a
+// longer run of letters and digits
+func maskWord(text, secret string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(text); {
+		j := strings.Index(text[i:], secret)
+		if j < 0 {
+			break
+		}
+		j += i
+		end := j + len(secret)
+		before, _ := utf8.DecodeLastRuneInString(text[:j])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(before) || isWordRune(after) {
+			i = j + 1
+			continue
+		}
+		b.WriteString(text[start:j])
+		b.WriteString(RedactedText)
+		start, i = end, end
+	}
+	b.WriteString(text[start:])
+	return b.String()
+}
+
+// isWordRune reports whether r would extend a secret into a longer word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Redact masks the session's password, token and credential headers in text
+func (s *Session) Redact(text string) string {
+	return Redact(text, s.secrets()...)
+}
+
+// NewAPIError builds an APIError like the package-level NewAPIError, with
+// the session's credentials masked in the body
+func (s *Session) NewAPIError(statusCode int, body []byte) *APIError {
+	return NewAPIError(statusCode, []byte(s.Redact(string(body))))
+}
+
+// secrets lists the credential values the session sends, longest first so a
+// password inside an encoded header value doesn't leave the rest behind
+func (s *Session) secrets() []string {
+	secrets := []string{s.Password}
+	if s.Profile != nil {
+		secrets = append(secrets, s.Profile.Password, s.Profile.TokenValue)
+	}
+	for key, value := range s.GetHeaders() {
+		switch http.CanonicalHeaderKey(key) {
+		case "Authorization", "Proxy-Authorization":
+			if _, credential, ok := strings.Cut(value, " "); ok {
+				value = credential
+			}
+			secrets = append(secrets, value)
+		case "Cookie":
+			for _, cookie := range strings.Split(value, ";") {
+				if _, credential, ok := strings.Cut(cookie, "="); ok {
+					secrets = append(secrets, strings.TrimSpace(credential))
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	return secrets
+}
+
+// redactError returns err with the session's credentials masked. A
+// *url.Error is rebuilt around its masked URL and cause, so errors.As still
+// finds one. Any other error that needed masking is replaced rather than
+// wrapped, since errors.As would otherwise hand back the original text;
+// errors.Is still matches what the original matched.
+func (s *Session) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if urlErr, ok := err.(*url.Error); ok {
+		cause := s.redactError(urlErr.Err)
+		location := s.Redact(urlErr.URL)
+		if cause == urlErr.Err && location == urlErr.URL {
+			return err
+		}
+		return &url.Error{Op: urlErr.Op, URL: location, Err: cause}
+	}
+
+	message := s.Redact(err.Error())
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
+
+// redactedError carries a masked message in place of an error. It doesn't
+// unwrap to the original, so its unmasked text stays out of reach.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Is lets errors.Is match sentinels such as context.DeadlineExceeded
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// Timeout reports whether the original was a timeout, as net.Error does
+func (e *redactedError) Timeout() bool {
+	var timeout interface{ Timeout() bool }
+	return errors.As(e.err, &timeout) && timeout.Timeout()
+}
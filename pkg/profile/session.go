@@ -1,10 +1,17 @@
 package profile
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,8 +22,24 @@ func (p *ZOSMFProfile) NewSession() (*Session, error) {
 		InsecureSkipVerify: !p.RejectUnauthorized,
 	}
 	
+	proxy, err := p.proxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	
 	transport := &http.Transport{
+		Proxy:           proxy,
 		TLSClientConfig: tlsConfig,
+		// How long to wait for "100 Continue" before sending a body anyway;
+		// only applies to requests that set "Expect: 100-continue"
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if options := p.Transport; options != nil {
+		transport.MaxIdleConns = options.MaxIdleConns
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+		transport.MaxConnsPerHost = options.MaxConnsPerHost
+		transport.IdleConnTimeout = options.IdleConnTimeout
+		transport.DisableKeepAlives = options.DisableKeepAlives
 	}
 	
 	client := &http.Client{
@@ -24,19 +47,49 @@ func (p *ZOSMFProfile) NewSession() (*Session, error) {
 		Timeout:   30 * time.Second,
 	}
 	
-	// Figure out protocol and build base URL
+	return newSession(p, client), nil
+}
+
+// proxyFunc picks the proxy for the profile's requests: HTTP(S)_PROXY unless
+// the profile names a proxy itself
+func (p *ZOSMFProfile) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if p.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	proxyURL, err := url.Parse(p.ProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", redactProxyURL(p.ProxyURL))
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+// NewSessionWithClient creates a session from a ZOSMF profile that sends its
+// requests through client, e.g. one sharing a transport with other sessions
+// or an instrumented one. The profile's TLS and Transport settings are not
+// applied; configure them on client. The session works on a shallow copy of
+// client, so installing its logging and dry-run hooks leaves client as is.
+func NewSessionWithClient(p *ZOSMFProfile, client *http.Client) (*Session, error) {
+	if p == nil {
+		return nil, fmt.Errorf("profile is nil")
+	}
+	if client == nil {
+		return nil, fmt.Errorf("http client is nil")
+	}
+	own := *client
+	return newSession(p, &own), nil
+}
+
+// newSession builds a session for a profile around an HTTP client
+func newSession(p *ZOSMFProfile, client *http.Client) *Session {
+	// Protocol comes only from the profile; the port says nothing about it
 	protocol := p.Protocol
 	if protocol == "" {
 		protocol = "https"
 	}
-	if p.Port == 80 {
-		protocol = "http"
-	} else if p.Port == 8080 {
-		protocol = "http"
-	}
 	
+	// Leave the port out only when it's the protocol's default
 	baseURL := protocol + "://" + p.Host
-	if p.Port != 0 && p.Port != 80 && p.Port != 443 {
+	if p.Port != 0 && p.Port != defaultPort(protocol) {
 		baseURL += ":" + fmt.Sprintf("%d", p.Port)
 	}
 
@@ -55,22 +108,55 @@ func (p *ZOSMFProfile) NewSession() (*Session, error) {
 	headers := map[string]string{
 		"Content-Type": "application/json",
 		"Accept":       "application/json",
+		"User-Agent":   DefaultUserAgent,
 	}
 	if p.User != "" && p.Password != "" {
 		b := base64.StdEncoding.EncodeToString([]byte(p.User + ":" + p.Password))
 		headers["Authorization"] = "Basic " + b
+	} else if p.TokenValue != "" {
+		tokenType := p.TokenType
+		if tokenType == "" {
+			tokenType = DefaultTokenType
+		}
+		headers["Cookie"] = tokenType + "=" + p.TokenValue
+	}
+	if p.ClientID != "" {
+		clientIDHeader := p.ClientIDHeader
+		if clientIDHeader == "" {
+			clientIDHeader = DefaultClientIDHeader
+		}
+		headers[clientIDHeader] = p.ClientID
 	}
 	
-	return &Session{
-		Profile:    p,
-		Host:       p.Host,
-		Port:       p.Port,
-		User:       p.User,
-		Password:   p.Password,
-		BaseURL:    baseURL,
-		HTTPClient: client,
-		Headers:    headers,
-	}, nil
+	session := &Session{
+		Profile:          p,
+		Host:             p.Host,
+		Port:             p.Port,
+		User:             p.User,
+		Password:         p.Password,
+		BaseURL:          baseURL,
+		HTTPClient:       client,
+		Headers:          headers,
+		MaxResponseBytes: DefaultMaxResponseBytes,
+	}
+	session.installTransport()
+	return session
+}
+
+// redactProxyURL hides a password in a proxy URL so it can go in an error
+func redactProxyURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil {
+		return u.Redacted()
+	}
+	return "<unparseable>"
+}
+
+// defaultPort is the port a URL implies for protocol
+func defaultPort(protocol string) int {
+	if strings.EqualFold(protocol, "http") {
+		return 80
+	}
+	return 443
 }
 
 // GetBaseURL returns the base URL for the session
@@ -83,17 +169,520 @@ func (s *Session) GetHTTPClient() *http.Client {
 	return s.HTTPClient
 }
 
-// GetHeaders returns the headers for the session
+// SetHTTPClient replaces the client the session sends requests through,
+// e.g. with a mock or instrumented one. Like NewSessionWithClient it keeps
+// a shallow copy so the session's logging and dry runs still apply. Call it
+// before the session is shared between goroutines.
+func (s *Session) SetHTTPClient(client *http.Client) {
+	if client == nil {
+		client = &http.Client{}
+	}
+	own := *client
+	s.HTTPClient = &own
+	s.installTransport()
+}
+
+// GetHeaders returns a copy of the session's headers, safe to range over
+// while other goroutines change them
 func (s *Session) GetHeaders() map[string]string {
-	return s.Headers
+	s.headersMu.RLock()
+	defer s.headersMu.RUnlock()
+	headers := make(map[string]string, len(s.Headers))
+	for key, value := range s.Headers {
+		headers[key] = value
+	}
+	return headers
 }
 
 // AddHeader adds a header to the session
 func (s *Session) AddHeader(key, value string) {
+	s.headersMu.Lock()
+	defer s.headersMu.Unlock()
+	if s.Headers == nil {
+		s.Headers = make(map[string]string)
+	}
 	s.Headers[key] = value
 }
 
 // RemoveHeader removes a header from the session
 func (s *Session) RemoveHeader(key string) {
+	s.headersMu.Lock()
+	defer s.headersMu.Unlock()
 	delete(s.Headers, key)
 }
+
+// SetUserAgent replaces the User-Agent sent on every request. An empty
+// string restores DefaultUserAgent.
+func (s *Session) SetUserAgent(userAgent string) {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	s.AddHeader("User-Agent", userAgent)
+}
+
+// ReadBody reads a response body up to the session's MaxResponseBytes.
+// When the body is larger it returns what fit along with ErrResponseTooLarge.
+func (s *Session) ReadBody(r io.Reader) ([]byte, error) {
+	limit := s.MaxResponseBytes
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return data, err
+	}
+	if int64(len(data)) > limit {
+		return data[:limit], fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, limit)
+	}
+	return data, nil
+}
+
+// DoRequest sends a request to path under the session's base URL (e.g.
+// "/restfiles/ds?dslevel=IBMUSER.*") with the session headers plus headers,
+// which take precedence. It's an escape hatch for calls the SDK doesn't
+// wrap: any status is returned as is rather than turned into an APIError,
+// and the error is only set when no response could be read.
+func (s *Session) DoRequest(method, path string, body io.Reader, headers map[string]string) (*Response, error) {
+	req, err := s.newRequest(context.Background(), method, path, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.GetHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", s.redactError(err))
+	}
+	defer resp.Body.Close()
+
+	data, err := s.ReadBody(resp.Body)
+	response := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+	if err != nil {
+		return response, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return response, nil
+}
+
+// DoRaw sends a request to path under the session's base URL with the
+// session headers. A non-2xx status comes back as an *APIError; otherwise
+// the caller gets the response and must close its body.
+func (s *Session) DoRaw(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return s.DoRawWithHeaders(ctx, method, path, body, nil)
+}
+
+// DoRawWithHeaders is DoRaw with extra request headers, which take
+// precedence over the session's
+func (s *Session) DoRawWithHeaders(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := s.newRequest(ctx, method, path, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.GetHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", s.redactError(err))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		data, _ := s.ReadBody(resp.Body)
+		return nil, s.NewAPIError(resp.StatusCode, data)
+	}
+	return resp, nil
+}
+
+// DoJSON sends body, when not nil, as JSON to path under the session's base
+// URL and decodes the reply into out, when not nil and the reply isn't
+// empty. Status handling is DoRaw's. Pass a *json.RawMessage as out to
+// decode the body yourself, e.g. with DecodeJSON in strict mode.
+func (s *Session) DoJSON(ctx context.Context, method, path string, body any, out any) error {
+	return s.DoJSONWithHeaders(ctx, method, path, body, out, nil)
+}
+
+// DoJSONWithHeaders is DoJSON with extra request headers, which take
+// precedence over the session's
+func (s *Session) DoJSONWithHeaders(ctx context.Context, method, path string, body any, out any, headers map[string]string) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	resp, err := s.DoRawWithHeaders(ctx, method, path, reader, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := s.ReadBody(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// DecodeJSON decodes a z/OSMF response body into v. With strict set it
+// rejects fields v doesn't know about, which is meant for tests and
+// validation runs that want to catch z/OSMF schema drift; leave it off in
+// production since new z/OSMF releases add fields. The managers' StrictJSON
+// flags are passed through here.
+func DecodeJSON(data []byte, v any, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+// newRequest builds a request for path under the base URL carrying the
+// session headers, then headers
+func (s *Session) newRequest(ctx context.Context, method, path string, body io.Reader, headers map[string]string) (*http.Request, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.GetBaseURL()+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range s.GetHeaders() {
+		req.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// ccsidInfoKeys are the /info fields that can carry the server's CCSID
+var ccsidInfoKeys = []string{"default_ccsid", "ccsid", "default_encoding", "encoding"}
+
+// GetDefaultCCSID returns the server's default CCSID, read from /info.
+// Not every z/OSMF release reports one; DefaultCCSID is returned then.
+// Only a successful lookup is cached, so a failed request is retried.
+func (s *Session) GetDefaultCCSID() (int, error) {
+	s.ccsidMu.Lock()
+	defer s.ccsidMu.Unlock()
+	if s.ccsid != 0 {
+		return s.ccsid, nil
+	}
+
+	var info map[string]interface{}
+	if err := s.DoJSON(context.Background(), "GET", "/info", nil, &info); err != nil {
+		return 0, err
+	}
+
+	ccsid := DefaultCCSID
+	for _, key := range ccsidInfoKeys {
+		if value, ok := info[key]; ok {
+			parsed, err := parseCCSID(value)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s in server info: %w", key, err)
+			}
+			ccsid = parsed
+			break
+		}
+	}
+
+	s.ccsid = ccsid
+	return ccsid, nil
+}
+
+// parseCCSID accepts a CCSID as a JSON number or as a string such as
+// "1047", "IBM-1047", "CP037" or "CCSID 500"
+func parseCCSID(value interface{}) (int, error) {
+	var ccsid int
+	switch v := value.(type) {
+	case float64:
+		ccsid = int(v)
+	case string:
+		name := strings.ToUpper(strings.TrimSpace(v))
+		for _, prefix := range []string{"IBM-", "IBM", "CP", "CCSID"} {
+			name = strings.TrimPrefix(name, prefix)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(name))
+		if err != nil {
+			return 0, fmt.Errorf("unrecognized encoding %q", v)
+		}
+		ccsid = n
+	default:
+		return 0, fmt.Errorf("unexpected CCSID value %v", value)
+	}
+
+	if ccsid <= 0 || ccsid > 65535 {
+		return 0, fmt.Errorf("CCSID %d out of range", ccsid)
+	}
+	return ccsid, nil
+}
+
+// diagnoseProbeTimeout bounds each probe made by DiagnoseConnection
+const diagnoseProbeTimeout = 10 * time.Second
+
+// DiagnoseConnection probes /info under the session's base path, the default
+// /zosmf path and no base path, over both https and http, and reports which
+// variants responded. Credentials are only sent to the https variants. It
+// returns an error only when none returned 200.
+func (s *Session) DiagnoseConnection(ctx context.Context) (*Diagnostics, error) {
+	base, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", s.BaseURL, err)
+	}
+
+	diag := &Diagnostics{}
+	for _, candidate := range diagnosticCandidates(base) {
+		result, resp := s.probe(ctx, candidate)
+		diag.Probes = append(diag.Probes, result)
+		if resp == nil {
+			continue
+		}
+
+		if diag.TLS == nil && resp.TLS != nil {
+			diag.TLS = tlsDetails(resp.TLS)
+		}
+		if diag.WorkingURL == "" && resp.StatusCode == http.StatusOK {
+			diag.WorkingURL = candidate
+			diag.ServerVersion = serverVersion(s, resp.Body)
+		}
+		resp.Body.Close()
+	}
+
+	if diag.WorkingURL == "" {
+		return diag, fmt.Errorf("none of %d candidate z/OSMF URLs responded with 200", len(diag.Probes))
+	}
+	return diag, nil
+}
+
+// diagnosticCandidates lists the /info URLs to try, session protocol first
+func diagnosticCandidates(base *url.URL) []string {
+	schemes := []string{base.Scheme, "https", "http"}
+	paths := []string{base.Path, "/zosmf", ""}
+
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, scheme := range schemes {
+		for _, path := range paths {
+			candidate := scheme + "://" + base.Host + path + "/info"
+			if !seen[candidate] {
+				seen[candidate] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// probe sends one GET and returns the open response, if any
+func (s *Session) probe(ctx context.Context, candidate string) (ProbeResult, *http.Response) {
+	result := ProbeResult{URL: candidate}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnoseProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", candidate, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	for key, value := range s.GetHeaders() {
+		// Never send credentials in cleartext to whatever answers on http
+		if req.URL.Scheme != "https" && isCredentialHeader(key) {
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := s.GetHTTPClient().Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = s.Redact(err.Error())
+		return result, nil
+	}
+
+	// Read the body while the probe context is still live
+	data, _ := s.ReadBody(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	result.StatusCode = resp.StatusCode
+	return result, resp
+}
+
+// isCredentialHeader reports whether a header carries the session's credentials
+func isCredentialHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Authorization", "Proxy-Authorization", "Cookie":
+		return true
+	}
+	return false
+}
+
+// serverVersion pulls the version out of a /info response body
+func serverVersion(s *Session, body io.Reader) string {
+	var info struct {
+		FullVersion string `json:"zosmf_full_version"`
+		Version     string `json:"zosmf_version"`
+	}
+	data, err := s.ReadBody(body)
+	if err != nil || json.Unmarshal(data, &info) != nil {
+		return ""
+	}
+	if info.FullVersion != "" {
+		return info.FullVersion
+	}
+	return info.Version
+}
+
+// tlsDetails summarizes a TLS connection state
+func tlsDetails(state *tls.ConnectionState) *TLSDetails {
+	details := &TLSDetails{
+		Version:       tls.VersionName(state.Version),
+		CipherSuite:   tls.CipherSuiteName(state.CipherSuite),
+		ServerName:    state.ServerName,
+		HandshakeDone: state.HandshakeComplete,
+	}
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		details.PeerSubject = cert.Subject.String()
+		details.PeerIssuer = cert.Issuer.String()
+		details.PeerNotAfter = cert.NotAfter
+	}
+	return details
+}
+
+// redactedHeaders are never passed to a Logger in clear text
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+}
+
+// SetLogger installs a Logger that sees every request the session's HTTP
+// client makes, from every manager. Passing nil installs NopLogger. Call it
+// before the session is shared between goroutines.
+func (s *Session) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	s.logger = logger
+	s.installTransport()
+}
+
+// LastPlannedRequest returns the most recent request DryRun held back, or nil
+func (s *Session) LastPlannedRequest() *PlannedRequest {
+	s.plannedMu.Lock()
+	defer s.plannedMu.Unlock()
+	return s.lastPlanned
+}
+
+// installTransport wraps the HTTP client's transport so logging and dry runs
+// see every request. It is a no-op when already wrapped.
+func (s *Session) installTransport() {
+	base := s.HTTPClient.Transport
+	if wrapped, ok := base.(*sessionTransport); ok {
+		if wrapped.session == s {
+			return
+		}
+		// Another session's wrapper; keep what it wraps
+		base = wrapped.base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	s.HTTPClient.Transport = &sessionTransport{base: base, session: s}
+}
+
+// sessionTransport applies the session's DryRun and Logger to each request
+type sessionTransport struct {
+	base    http.RoundTripper
+	session *Session
+}
+
+// RoundTrip performs the request, or plans it in dry-run mode, and logs it
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := t.session
+	info := RequestInfo{
+		Method:  req.Method,
+		URL:     s.Redact(redactURL(req.URL)),
+		Headers: redactHeaders(req.Header),
+	}
+
+	start := time.Now()
+	var resp *http.Response
+	var err error
+	if s.DryRun && isMutating(req.Method) {
+		err = s.plan(req, info)
+	} else {
+		resp, err = t.base.RoundTrip(req)
+		err = s.redactError(err)
+	}
+
+	if s.logger != nil {
+		result := ResponseInfo{Duration: time.Since(start), Err: err}
+		if resp != nil {
+			result.StatusCode = resp.StatusCode
+		}
+		s.logger.LogRequest(info, result)
+	}
+
+	return resp, err
+}
+
+// plan records a request DryRun held back and returns it as the error
+func (s *Session) plan(req *http.Request, info RequestInfo) error {
+	planned := &PlannedRequest{Method: info.Method, URL: info.URL, Headers: info.Headers}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read planned request body: %w", err)
+		}
+		planned.Body = body
+	}
+
+	s.plannedMu.Lock()
+	s.lastPlanned = planned
+	s.plannedMu.Unlock()
+
+	return planned
+}
+
+// isMutating reports whether a method changes anything on the server
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// redactHeaders flattens headers with credentials replaced
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(key)] {
+			headers[key] = RedactedText
+		} else {
+			headers[key] = header.Get(key)
+		}
+	}
+	return headers
+}
+
+// redactURL drops any password embedded in a URL
+func redactURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	clean := *u
+	clean.User = url.User(u.User.Username())
+	return clean.String()
+}
@@ -1,7 +1,11 @@
 package profile
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 )
 
 
@@ -36,8 +40,38 @@ type ZOSMFProfile struct {
 	ResponseTimeout    int    `json:"responseTimeout,omitempty"`
 	CertFile           string `json:"certFile,omitempty"`
 	CertKeyFile        string `json:"certKeyFile,omitempty"`
+	ClientID           string `json:"clientId,omitempty"`       // Sent on every request for audit correlation
+	ClientIDHeader     string `json:"clientIdHeader,omitempty"` // Header name for ClientID (default X-Client-ID)
+	TokenType          string `json:"tokenType,omitempty"`      // Cookie name for TokenValue (default LtpaToken2)
+	TokenValue         string `json:"tokenValue,omitempty"`     // Used when there's no user and password
+	ProxyURL           string `json:"proxyUrl,omitempty"`       // Overrides HTTP(S)_PROXY; may carry user:password
+
+	// Transport tunes the connection pool NewSession sets up; nil keeps Go's defaults
+	Transport *TransportOptions `json:"-"`
+}
+
+// TransportOptions tunes the HTTP connection pool of a session. Zero values
+// keep Go's defaults (http.Transport semantics).
+type TransportOptions struct {
+	MaxIdleConns        int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           // Idle connections kept per host; raise for parallel batch work
+	MaxConnsPerHost     int           // Cap on connections per host, idle or not
+	IdleConnTimeout     time.Duration // How long an idle connection is kept
+	DisableKeepAlives   bool          // Open a new connection for every request
 }
 
+// DefaultTokenType is the cookie TokenValue is sent in unless TokenType is set
+const DefaultTokenType = "LtpaToken2"
+
+// Version is the SDK release, sent in the default User-Agent
+const Version = "0.1.0"
+
+// DefaultUserAgent identifies SDK traffic in z/OSMF audit and SMF records
+const DefaultUserAgent = "zowe-client-go-sdk/" + Version
+
+// DefaultClientIDHeader is the header ClientID is sent in unless the profile overrides it
+const DefaultClientIDHeader = "X-Client-ID"
+
 // BaseProfile represents the global base profile properties
 type BaseProfile struct {
 	Host               string `json:"host"`
@@ -60,7 +94,147 @@ type Session struct {
 	Password   string
 	BaseURL    string
 	HTTPClient *http.Client
-	Headers    map[string]string
+
+	// Headers are sent on every request. Change them with AddHeader and
+	// RemoveHeader, and read them with GetHeaders, once the session is
+	// shared between goroutines.
+	Headers   map[string]string
+	headersMu sync.RWMutex
+
+	// MaxResponseBytes caps how much of a response body is read into memory
+	// by non-streaming calls. Zero or less means DefaultMaxResponseBytes.
+	MaxResponseBytes int64
+
+	// DryRun holds back every request that would change something (anything
+	// but GET, HEAD and OPTIONS). The call fails with a *PlannedRequest
+	// describing exactly what would have been sent; reads still go through.
+	DryRun bool
+
+	ccsidMu sync.Mutex
+	ccsid   int // Cached by GetDefaultCCSID once known
+
+	logger      Logger
+	plannedMu   sync.Mutex
+	lastPlanned *PlannedRequest
+}
+
+// PlannedRequest is a request held back by Session.DryRun. It is returned as
+// the error of the call that would have sent it; use errors.As to get it.
+// Credentials in the headers are redacted.
+type PlannedRequest struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Error describes the request that was not sent
+func (p *PlannedRequest) Error() string {
+	return fmt.Sprintf("dry run: %s %s not sent", p.Method, p.URL)
+}
+
+// IsDryRun reports whether err came from a request held back by DryRun
+func IsDryRun(err error) bool {
+	var planned *PlannedRequest
+	return errors.As(err, &planned)
+}
+
+// DefaultCCSID is assumed when the server doesn't report one (IBM-1047, the
+// z/OS default EBCDIC code page)
+const DefaultCCSID = 1047
+
+// Logger receives one call per HTTP request made through a Session.
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	LogRequest(req RequestInfo, resp ResponseInfo)
+}
+
+// RequestInfo describes an outgoing request. Credentials are redacted.
+type RequestInfo struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+}
+
+// ResponseInfo describes the outcome of a request
+type ResponseInfo struct {
+	StatusCode int           // 0 when no response was received
+	Duration   time.Duration // Time until the response headers arrived
+	Err        error         // Transport error, if any
+}
+
+// NopLogger discards everything; it is what SetLogger(nil) installs
+type NopLogger struct{}
+
+// LogRequest does nothing
+func (NopLogger) LogRequest(RequestInfo, ResponseInfo) {}
+
+// Diagnostics reports which z/OSMF URL variants answered a connection probe
+type Diagnostics struct {
+	Probes        []ProbeResult // Every candidate tried, in order
+	WorkingURL    string        // First candidate that returned 200, if any
+	ServerVersion string        // zosmf_full_version (or zosmf_version) from the working URL
+	TLS           *TLSDetails   // TLS details from the first https candidate that connected
+}
+
+// ProbeResult is the outcome of probing one candidate URL
+type ProbeResult struct {
+	URL        string
+	StatusCode int    // 0 when no HTTP response was received
+	Error      string // Transport error, if any
+	Duration   time.Duration
+}
+
+// TLSDetails describes the TLS connection to the server
+type TLSDetails struct {
+	Version       string
+	CipherSuite   string
+	ServerName    string
+	PeerSubject   string
+	PeerIssuer    string
+	PeerNotAfter  time.Time
+	HandshakeDone bool
+}
+
+// Response is a z/OSMF reply as Session.DoRequest returns it, for callers
+// that need headers such as X-IBM-Record-Count or Location
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte // Read up to the session's MaxResponseBytes
+}
+
+// DefaultMaxResponseBytes is the response size limit new sessions start with
+const DefaultMaxResponseBytes int64 = 256 << 20
+
+// ErrResponseTooLarge is returned when a response body exceeds MaxResponseBytes
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ErrNoSession is returned by managers that were created without a session
+var ErrNoSession = errors.New("manager has no session")
+
+// ErrSecureValueMissing is returned when a profile lists a property under
+// "secure" but the value isn't in the config and no resolver supplied it
+var ErrSecureValueMissing = errors.New("secure value not available")
+
+// SecureValueResolver looks up a secure property that isn't inlined in the
+// config, such as a password Zowe CLI keeps in the OS keyring. profilePath
+// is the dotted profile name ("lpar1.zosmf"). Return "" when unknown.
+type SecureValueResolver func(profilePath, property string) (string, error)
+
+// ProblemSeverity ranks a ConfigProblem
+type ProblemSeverity string
+
+const (
+	SeverityError   ProblemSeverity = "error"   // The profile can't be used as written
+	SeverityWarning ProblemSeverity = "warning" // Usable, but insecure or suspicious
+)
+
+// ConfigProblem is one issue found by ZOSMFProfileManager.Validate
+type ConfigProblem struct {
+	Path     string // JSON path in the config, e.g. "profiles.zosmf.properties.port"
+	Severity ProblemSeverity
+	Message  string
 }
 
 // ProfileManager interface for managing profiles
@@ -73,5 +247,12 @@ type ProfileManager interface {
 
 // ZOSMFProfileManager implements ProfileManager for ZOSMF profiles
 type ZOSMFProfileManager struct {
-	configPath string
+	configPath      string
+	allowMissing    bool                // Treat a missing config file as an empty config
+	secureResolver  SecureValueResolver // Supplies secure values not inlined in the config
+	credentialStore CredentialStore     // Holds passwords and tokens saved outside the config
+
+	// UsePlaintext writes passwords and tokens into the config file even
+	// when a CredentialStore is set, for environments without a keyring
+	UsePlaintext bool
 } 
\ No newline at end of file
@@ -0,0 +1,211 @@
+package profile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// propertyKind is the JSON type a known profile property must have
+type propertyKind int
+
+const (
+	kindString propertyKind = iota
+	kindBool
+	kindPort
+	kindNumber
+)
+
+// knownProperties lists the profile properties the SDK understands
+var knownProperties = map[string]propertyKind{
+	"host":               kindString,
+	"port":               kindPort,
+	"user":               kindString,
+	"password":           kindString,
+	"rejectUnauthorized": kindBool,
+	"basePath":           kindString,
+	"protocol":           kindString,
+	"encoding":           kindString,
+	"responseTimeout":    kindNumber,
+	"certFile":           kindString,
+	"certKeyFile":        kindString,
+	"clientId":           kindString,
+	"clientIdHeader":     kindString,
+	"tokenType":          kindString,
+	"tokenValue":         kindString,
+	"proxyUrl":           kindString,
+}
+
+// Validate loads the config and reports every problem it finds in one pass:
+// profiles missing required fields, defaults naming profiles that don't
+// exist, properties of the wrong type and insecure settings. The error is
+// only set when the config can't be loaded at all.
+func (pm *ZOSMFProfileManager) Validate() ([]ConfigProblem, error) {
+	config, err := pm.loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	v := &configValidator{profiles: make(map[string]ZoweProfile)}
+	v.collect("", config.Profiles)
+
+	// Host and port can come from the base profile or enclosing profiles
+	var base map[string]interface{}
+	if basePath := baseProfilePath(config); basePath != "" {
+		base = inheritedProperties(profileChain(config.Profiles, basePath))
+	}
+
+	for _, name := range v.sortedNames() {
+		inherited := inheritedProperties(profileChain(config.Profiles, name))
+		for key, value := range base {
+			if _, ok := inherited[key]; !ok {
+				inherited[key] = value
+			}
+		}
+		v.checkProfile(name, v.profiles[name], inherited)
+	}
+
+	defaultTypes := make([]string, 0, len(config.Defaults))
+	for profileType := range config.Defaults {
+		defaultTypes = append(defaultTypes, profileType)
+	}
+	sort.Strings(defaultTypes)
+	for _, profileType := range defaultTypes {
+		name := config.Defaults[profileType]
+		path := "defaults." + profileType
+		profile, exists := v.profiles[name]
+		switch {
+		case !exists:
+			v.add(path, SeverityError, "default %s profile %q does not exist", profileType, name)
+		case profile.Type != profileType:
+			v.add(path, SeverityError, "default %s profile %q has type %q", profileType, name, profile.Type)
+		}
+	}
+
+	return v.problems, nil
+}
+
+// configValidator accumulates problems found while validating a config
+type configValidator struct {
+	profiles map[string]ZoweProfile // Keyed by dotted profile name, nested included
+	problems []ConfigProblem
+}
+
+// add records a problem
+func (v *configValidator) add(path string, severity ProblemSeverity, format string, args ...interface{}) {
+	v.problems = append(v.problems, ConfigProblem{Path: path, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// collect flattens nested profiles into dotted names ("lpar1.zosmf")
+func (v *configValidator) collect(prefix string, profiles map[string]ZoweProfile) {
+	walkProfiles(prefix, profiles, func(path string, profile ZoweProfile) {
+		v.profiles[path] = profile
+	})
+}
+
+// sortedNames returns profile names in a stable order
+func (v *configValidator) sortedNames() []string {
+	names := make([]string, 0, len(v.profiles))
+	for name := range v.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profilePath is the JSON path of a dotted profile name
+func profilePath(name string) string {
+	return "profiles." + strings.ReplaceAll(name, ".", ".profiles.")
+}
+
+// checkProfile validates one profile's type and properties. inherited holds
+// what the profile picks up from its parents and the base profile.
+func (v *configValidator) checkProfile(name string, profile ZoweProfile, inherited map[string]interface{}) {
+	path := profilePath(name)
+
+	// Grouping profiles only hold nested profiles
+	if profile.Type == "" {
+		if len(profile.Profiles) == 0 {
+			v.add(path+".type", SeverityError, "profile %q has no type", name)
+		}
+		return
+	}
+
+	props := profile.Properties
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if kind, known := knownProperties[key]; known {
+			if msg := checkKind(props[key], kind); msg != "" {
+				v.add(path+".properties."+key, SeverityError, "%s %s", key, msg)
+			}
+		}
+	}
+
+	if profile.Type == "zosmf" {
+		for _, required := range []string{"host", "port"} {
+			if _, ok := props[required]; !ok {
+				if _, ok := inherited[required]; !ok {
+					v.add(path+".properties."+required, SeverityError, "%s is required and not set here, in an enclosing profile or in the base profile", required)
+				}
+			}
+		}
+		if protocol, ok := props["protocol"].(string); ok {
+			switch protocol {
+			case "https":
+			case "http":
+				v.add(path+".properties.protocol", SeverityWarning, "protocol http sends credentials unencrypted")
+			default:
+				v.add(path+".properties.protocol", SeverityError, "protocol must be http or https, not %q", protocol)
+			}
+		}
+	}
+
+	if reject, ok := props["rejectUnauthorized"].(bool); ok && !reject {
+		v.add(path+".properties.rejectUnauthorized", SeverityWarning, "rejectUnauthorized false disables TLS certificate checks")
+	}
+	if _, ok := props["password"]; ok && !isSecure(profile, "password") {
+		v.add(path+".properties.password", SeverityWarning, "password is stored in plain text; list it under secure")
+	}
+}
+
+// checkKind describes how value fails to be of kind, or returns ""
+func checkKind(value interface{}, kind propertyKind) string {
+	switch kind {
+	case kindString:
+		if _, ok := value.(string); !ok {
+			return "must be a string"
+		}
+	case kindBool:
+		if _, ok := value.(bool); !ok {
+			return "must be true or false"
+		}
+	case kindNumber:
+		if _, ok := value.(float64); !ok {
+			return "must be a number"
+		}
+	case kindPort:
+		port, ok := value.(float64)
+		if !ok {
+			return "must be a number"
+		}
+		if port != math.Trunc(port) || port < 1 || port > 65535 {
+			return fmt.Sprintf("must be a whole number from 1 to 65535, not %v", port)
+		}
+	}
+	return ""
+}
+
+// isSecure reports whether a property is listed in the profile's secure array
+func isSecure(profile ZoweProfile, property string) bool {
+	for _, name := range profile.Secure {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
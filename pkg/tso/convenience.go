@@ -0,0 +1,47 @@
+package tso
+
+import (
+	"fmt"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// CreateTSOManager creates a TSO manager from a profile manager
+func CreateTSOManager(pm *profile.ZOSMFProfileManager, profileName string) (*ZOSMFTSOManager, error) {
+	zosmfProfile, err := pm.GetZOSMFProfile(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ZOSMF profile '%s': %w", profileName, err)
+	}
+
+	session, err := zosmfProfile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewTSOManager(session), nil
+}
+
+// CreateTSOManagerDirect creates a TSO manager with connection details
+func CreateTSOManagerDirect(host string, port int, user, password string) (*ZOSMFTSOManager, error) {
+	session, err := profile.CreateSessionDirect(host, port, user, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewTSOManager(session), nil
+}
+
+// CreateTSOManagerDirectWithOptions creates a TSO manager with extra options
+func CreateTSOManagerDirectWithOptions(host string, port int, user, password string, rejectUnauthorized bool, basePath string) (*ZOSMFTSOManager, error) {
+	session, err := profile.CreateSessionDirectWithOptions(host, port, user, password, rejectUnauthorized, basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewTSOManager(session), nil
+}
+
+// IssueCommandWithAccount runs a TSO command using the given account number
+func (tm *ZOSMFTSOManager) IssueCommandWithAccount(command, account string) (*TSOResponse, error) {
+	return tm.IssueCommandWithOptions(command, &StartRequest{Account: account})
+}
@@ -0,0 +1,271 @@
+package tso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// z/OSMF TSO servlet endpoints
+const (
+	// Start a TSO address space
+	TSOEndpoint = "/tsoApp/tso"
+
+	// Send to, read from or stop an address space by servlet key
+	TSOServletEndpoint = "/tsoApp/tso/%s"
+)
+
+// Defaults used when starting an address space
+const (
+	DefaultProc       = "IKJACCNT"
+	DefaultCharSet    = "697"
+	DefaultCodePage   = "1047"
+	DefaultRows       = 204
+	DefaultCols       = 160
+	DefaultRegionSize = 4096
+)
+
+// maxResponseReads bounds how many times a response is polled while
+// waiting for the address space to prompt for more input
+const maxResponseReads = 50
+
+// NewTSOManager creates a TSO manager with the given session
+func NewTSOManager(session *profile.Session) *ZOSMFTSOManager {
+	return &ZOSMFTSOManager{
+		session: session,
+	}
+}
+
+// getSession returns the manager's session, or profile.ErrNoSession when
+// the manager was created with a nil one
+func (tm *ZOSMFTSOManager) getSession() (*profile.Session, error) {
+	if tm.session == nil {
+		return nil, profile.ErrNoSession
+	}
+	return tm.session, nil
+}
+
+// NewTSOManagerFromProfile creates a TSO manager from a profile
+func NewTSOManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFTSOManager, error) {
+	session, err := profile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return NewTSOManager(session), nil
+}
+
+// IssueCommand runs a TSO command in a new address space with default
+// start parameters and stops the address space afterwards
+func (tm *ZOSMFTSOManager) IssueCommand(command string) (*TSOResponse, error) {
+	return tm.IssueCommandWithOptions(command, nil)
+}
+
+// IssueCommandWithOptions runs a TSO command in a new address space started
+// with the given parameters and stops the address space afterwards
+func (tm *ZOSMFTSOManager) IssueCommandWithOptions(command string, request *StartRequest) (*TSOResponse, error) {
+	if command == "" {
+		return nil, fmt.Errorf("command cannot be empty")
+	}
+
+	started, err := tm.StartTSO(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start TSO address space: %w", err)
+	}
+	servletKey := started.ServletKey
+
+	startData, err := tm.readUntilPrompt(started)
+	if err != nil {
+		tm.StopTSO(servletKey)
+		return nil, err
+	}
+
+	sent, err := tm.SendCommand(servletKey, command)
+	if err != nil {
+		tm.StopTSO(servletKey)
+		return nil, fmt.Errorf("failed to send command: %w", err)
+	}
+
+	commandData, err := tm.readUntilPrompt(sent)
+	if err != nil {
+		tm.StopTSO(servletKey)
+		return nil, err
+	}
+
+	if err := tm.StopTSO(servletKey); err != nil {
+		return nil, fmt.Errorf("failed to stop TSO address space: %w", err)
+	}
+
+	return &TSOResponse{
+		ServletKey:    servletKey,
+		Messages:      messageLines(commandData),
+		StartMessages: messageLines(startData),
+	}, nil
+}
+
+// StartTSO starts a TSO address space. A nil request uses the defaults.
+func (tm *ZOSMFTSOManager) StartTSO(request *StartRequest) (*TSOServletResponse, error) {
+	session, err := tm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	params := startParams(request)
+	return tm.doServlet(session, "POST", TSOEndpoint+"?"+params.Encode(), nil)
+}
+
+// SendCommand sends a command to a running address space and returns the
+// first batch of output
+func (tm *ZOSMFTSOManager) SendCommand(servletKey, command string) (*TSOServletResponse, error) {
+	session, err := tm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create request body
+	requestBody := map[string]interface{}{
+		"TSO RESPONSE": map[string]string{
+			"VERSION": "0100",
+			"DATA":    command,
+		},
+	}
+
+	path := fmt.Sprintf(TSOServletEndpoint, url.PathEscape(servletKey)) + "?readReply=true"
+	return tm.doServlet(session, "PUT", path, requestBody)
+}
+
+// GetResponse reads pending output from a running address space
+func (tm *ZOSMFTSOManager) GetResponse(servletKey string) (*TSOServletResponse, error) {
+	session, err := tm.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.doServlet(session, "GET", fmt.Sprintf(TSOServletEndpoint, url.PathEscape(servletKey)), nil)
+}
+
+// StopTSO stops a running address space
+func (tm *ZOSMFTSOManager) StopTSO(servletKey string) error {
+	session, err := tm.getSession()
+	if err != nil {
+		return err
+	}
+
+	_, err = tm.doServlet(session, "DELETE", fmt.Sprintf(TSOServletEndpoint, url.PathEscape(servletKey)), nil)
+	return err
+}
+
+// doServlet sends a servlet request and decodes the response
+func (tm *ZOSMFTSOManager) doServlet(session *profile.Session, method, path string, body interface{}) (*TSOServletResponse, error) {
+	var raw json.RawMessage
+	if err := session.DoJSON(context.Background(), method, path, body, &raw); err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var servletResp TSOServletResponse
+	if err := profile.DecodeJSON(raw, &servletResp, tm.StrictJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &servletResp, nil
+}
+
+// readUntilPrompt collects tsoData starting from resp, polling the address
+// space until it prompts for input or the servlet reports a timeout
+func (tm *ZOSMFTSOManager) readUntilPrompt(resp *TSOServletResponse) ([]TSOData, error) {
+	data := append([]TSOData{}, resp.TSOData...)
+	for reads := 0; !hasPrompt(resp.TSOData) && !resp.Timeout; reads++ {
+		if reads >= maxResponseReads {
+			return data, fmt.Errorf("no TSO prompt after %d reads", maxResponseReads)
+		}
+
+		next, err := tm.GetResponse(resp.ServletKey)
+		if err != nil {
+			return data, fmt.Errorf("failed to read response: %w", err)
+		}
+		if next.ServletKey == "" {
+			next.ServletKey = resp.ServletKey
+		}
+		resp = next
+		data = append(data, resp.TSOData...)
+	}
+	return data, nil
+}
+
+// startParams builds the query parameters for starting an address space
+func startParams(request *StartRequest) url.Values {
+	r := StartRequest{}
+	if request != nil {
+		r = *request
+	}
+	if r.Proc == "" {
+		r.Proc = DefaultProc
+	}
+	if r.CharSet == "" {
+		r.CharSet = DefaultCharSet
+	}
+	if r.CodePage == "" {
+		r.CodePage = DefaultCodePage
+	}
+	if r.Rows == 0 {
+		r.Rows = DefaultRows
+	}
+	if r.Cols == 0 {
+		r.Cols = DefaultCols
+	}
+	if r.RegionSize == 0 {
+		r.RegionSize = DefaultRegionSize
+	}
+
+	params := url.Values{}
+	if r.Account != "" {
+		params.Set("acct", r.Account)
+	}
+	params.Set("proc", r.Proc)
+	params.Set("chset", r.CharSet)
+	params.Set("cpage", r.CodePage)
+	params.Set("rows", strconv.Itoa(r.Rows))
+	params.Set("cols", strconv.Itoa(r.Cols))
+	params.Set("rsize", strconv.Itoa(r.RegionSize))
+	return params
+}
+
+// hasPrompt reports whether the address space asked for more input
+func hasPrompt(data []TSOData) bool {
+	for _, d := range data {
+		if d.Prompt != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// messageLines extracts the message text from tsoData entries
+func messageLines(data []TSOData) []string {
+	lines := []string{}
+	for _, d := range data {
+		if d.Message != nil {
+			lines = append(lines, d.Message.Data)
+		}
+	}
+	return lines
+}
+
+// CloseTSOManager closes the TSO manager and its underlying HTTP connections
+func (tm *ZOSMFTSOManager) CloseTSOManager() error {
+	session := tm.session
+	if session == nil {
+		return nil
+	}
+
+	// Close idle connections in the HTTP client
+	if client := session.GetHTTPClient(); client != nil {
+		client.CloseIdleConnections()
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package tso
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestProfile creates a profile for testing with the given server URL
+func createTestProfile(serverURL string) *profile.ZOSMFProfile {
+	// Extract host and port from server URL
+	host := strings.TrimPrefix(serverURL, "http://")
+	host = strings.TrimPrefix(host, "https://")
+
+	return &profile.ZOSMFProfile{
+		Name:               "test",
+		Host:               host,
+		Port:               0, // Let the session determine the port from the URL
+		User:               "testuser",
+		Password:           "testpass",
+		RejectUnauthorized: false,
+		BasePath:           "/api/v1",
+		Protocol:           "http", // Force HTTP for test server
+	}
+}
+
+// newTestManager creates a TSO manager pointed at a test server
+func newTestManager(t *testing.T, handler http.HandlerFunc) (*ZOSMFTSOManager, func()) {
+	server := httptest.NewServer(handler)
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	return NewTSOManager(session), server.Close
+}
+
+func TestNewTSOManager(t *testing.T) {
+	session, err := createTestProfile("localhost:8080").NewSession()
+	require.NoError(t, err)
+
+	tm := NewTSOManager(session)
+	assert.NotNil(t, tm)
+	assert.Equal(t, session, tm.session)
+
+	tm, err = NewTSOManagerFromProfile(createTestProfile("localhost:8080"))
+	require.NoError(t, err)
+	assert.NotNil(t, tm)
+}
+
+func TestNewTSOManagerNilSession(t *testing.T) {
+	// A manager built without a session reports an error instead of panicking
+	tm := NewTSOManager(nil)
+
+	_, err := tm.IssueCommand("TIME")
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	assert.ErrorIs(t, tm.StopTSO("KEY"), profile.ErrNoSession)
+
+	assert.NoError(t, tm.CloseTSOManager())
+}
+
+func TestCreateTSOManager(t *testing.T) {
+	pm := &profile.ZOSMFProfileManager{}
+
+	// This should fail since we don't have a real profile
+	_, err := CreateTSOManager(pm, "nonexistent")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get ZOSMF profile")
+
+	tm, err := CreateTSOManagerDirect("localhost", 8080, "testuser", "testpass")
+	require.NoError(t, err)
+	assert.NotNil(t, tm)
+
+	tm, err = CreateTSOManagerDirectWithOptions("localhost", 8080, "testuser", "testpass", false, "/api/v1")
+	require.NoError(t, err)
+	assert.NotNil(t, tm)
+}
+
+func TestIssueCommand(t *testing.T) {
+	const key = "TESTUSER-71-aabcaaaf"
+	var calls []string
+	reads := 0
+	tm, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "POST":
+			assert.Equal(t, "/api/v1/tsoApp/tso", r.URL.Path)
+			assert.Equal(t, "ACCT#", r.URL.Query().Get("acct"))
+			assert.Equal(t, DefaultProc, r.URL.Query().Get("proc"))
+			assert.Equal(t, "4096", r.URL.Query().Get("rsize"))
+			w.Write([]byte(`{"servletKey":"` + key + `","queueID":"4","ver":"0100","reused":false,"timeout":false,"tsoData":[
+				{"TSO MESSAGE":{"VERSION":"0100","DATA":"IKJ56455I TESTUSER LOGON IN PROGRESS"}},
+				{"TSO PROMPT":{"VERSION":"0100","HIDDEN":"FALSE"}}
+			]}`))
+		case r.Method == "PUT":
+			assert.Equal(t, "/api/v1/tsoApp/tso/"+key, r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("readReply"))
+			var body map[string]map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "TIME", body["TSO RESPONSE"]["DATA"])
+			// First batch has output but no prompt yet
+			w.Write([]byte(`{"servletKey":"` + key + `","ver":"0100","tsoData":[
+				{"TSO MESSAGE":{"VERSION":"0100","DATA":"IKJ56650I TIME-10:15:00 AM."}}
+			]}`))
+		case r.Method == "GET":
+			assert.Equal(t, "/api/v1/tsoApp/tso/"+key, r.URL.Path)
+			reads++
+			w.Write([]byte(`{"servletKey":"` + key + `","ver":"0100","tsoData":[
+				{"TSO MESSAGE":{"VERSION":"0100","DATA":"READY"}},
+				{"TSO PROMPT":{"VERSION":"0100","HIDDEN":"FALSE"}}
+			]}`))
+		case r.Method == "DELETE":
+			assert.Equal(t, "/api/v1/tsoApp/tso/"+key, r.URL.Path)
+			w.Write([]byte(`{"servletKey":"` + key + `","ver":"0100","reused":false,"timeout":false}`))
+		}
+	})
+	defer closeServer()
+
+	resp, err := tm.IssueCommandWithAccount("TIME", "ACCT#")
+	require.NoError(t, err)
+	assert.Equal(t, key, resp.ServletKey)
+	assert.Equal(t, []string{"IKJ56650I TIME-10:15:00 AM.", "READY"}, resp.Messages)
+	assert.Equal(t, []string{"IKJ56455I TESTUSER LOGON IN PROGRESS"}, resp.StartMessages)
+	assert.Equal(t, 1, reads)
+	assert.Equal(t, []string{"POST", "PUT", "GET", "DELETE"}, calls)
+}
+
+func TestIssueCommandStopsOnError(t *testing.T) {
+	stopped := false
+	tm, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.Write([]byte(`{"servletKey":"KEY1","tsoData":[{"TSO PROMPT":{"VERSION":"0100"}}]}`))
+		case "PUT":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("servlet error"))
+		case "DELETE":
+			stopped = true
+			w.Write([]byte(`{"servletKey":"KEY1"}`))
+		}
+	})
+	defer closeServer()
+
+	_, err := tm.IssueCommand("TIME")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+	assert.True(t, stopped)
+
+	_, err = tm.IssueCommand("")
+	assert.Error(t, err)
+}
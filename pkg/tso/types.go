@@ -0,0 +1,62 @@
+package tso
+
+import "github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+
+// StartRequest holds the parameters for starting a TSO address space
+type StartRequest struct {
+	Account    string `json:"acct"`  // Accounting information (required by most systems)
+	Proc       string `json:"proc"`  // Logon procedure, e.g. IKJACCNT
+	CharSet    string `json:"chset"` // Character set, e.g. 697
+	CodePage   string `json:"cpage"` // Code page, e.g. 1047
+	Rows       int    `json:"rows"`  // Screen rows
+	Cols       int    `json:"cols"`  // Screen columns
+	RegionSize int    `json:"rsize"` // Region size in KB
+}
+
+// TSOMessage is the payload of a single tsoData entry
+type TSOMessage struct {
+	Version string `json:"VERSION"`
+	Data    string `json:"DATA"`
+	Hidden  string `json:"HIDDEN,omitempty"`
+}
+
+// TSOData is one entry of the tsoData array. Exactly one field is set.
+type TSOData struct {
+	Message *TSOMessage `json:"TSO MESSAGE,omitempty"` // Output line
+	Prompt  *TSOMessage `json:"TSO PROMPT,omitempty"`  // Address space is waiting for input
+}
+
+// TSOServletResponse represents a z/OSMF TSO servlet response
+type TSOServletResponse struct {
+	ServletKey string    `json:"servletKey"`
+	QueueID    string    `json:"queueID,omitempty"`
+	Version    string    `json:"ver,omitempty"`
+	Reused     bool      `json:"reused,omitempty"`
+	Timeout    bool      `json:"timeout,omitempty"`
+	TSOData    []TSOData `json:"tsoData"`
+}
+
+// TSOResponse is the result of issuing a TSO command
+type TSOResponse struct {
+	ServletKey    string   `json:"servletKey"`    // Key of the address space the command ran in
+	Messages      []string `json:"messages"`      // Command output lines
+	StartMessages []string `json:"startMessages"` // Logon messages from starting the address space
+}
+
+// TSOManager interface for TSO/E command operations
+type TSOManager interface {
+	IssueCommand(command string) (*TSOResponse, error)
+	IssueCommandWithOptions(command string, request *StartRequest) (*TSOResponse, error)
+	StartTSO(request *StartRequest) (*TSOServletResponse, error)
+	SendCommand(servletKey, command string) (*TSOServletResponse, error)
+	GetResponse(servletKey string) (*TSOServletResponse, error)
+	StopTSO(servletKey string) error
+}
+
+// ZOSMFTSOManager implements TSOManager for ZOSMF
+type ZOSMFTSOManager struct {
+	session *profile.Session
+
+	// StrictJSON decodes responses in profile.DecodeJSON's strict mode
+	StrictJSON bool
+}
@@ -0,0 +1,125 @@
+package uss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// CreateUSSManager creates a USS manager from a profile manager
+func CreateUSSManager(pm *profile.ZOSMFProfileManager, profileName string) (*ZOSMFUSSManager, error) {
+	zosmfProfile, err := pm.GetZOSMFProfile(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ZOSMF profile '%s': %w", profileName, err)
+	}
+
+	session, err := zosmfProfile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewUSSManager(session), nil
+}
+
+// CreateUSSManagerDirect creates a USS manager with connection details
+func CreateUSSManagerDirect(host string, port int, user, password string) (*ZOSMFUSSManager, error) {
+	session, err := profile.CreateSessionDirect(host, port, user, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewUSSManager(session), nil
+}
+
+// CreateUSSManagerDirectWithOptions creates a USS manager with extra options
+func CreateUSSManagerDirectWithOptions(host string, port int, user, password string, rejectUnauthorized bool, basePath string) (*ZOSMFUSSManager, error) {
+	session, err := profile.CreateSessionDirectWithOptions(host, port, user, password, rejectUnauthorized, basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return NewUSSManager(session), nil
+}
+
+// ValidatePath validates an absolute z/OS UNIX path
+func ValidatePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path must be absolute")
+	}
+	if len(path) > 1023 {
+		return fmt.Errorf("path cannot exceed 1023 characters")
+	}
+	if strings.ContainsRune(path, 0) {
+		return fmt.Errorf("path cannot contain NUL characters")
+	}
+	return nil
+}
+
+// IsDirectory reports whether a listing entry is a directory
+func (f *USSFile) IsDirectory() bool {
+	return strings.HasPrefix(f.Mode, "d")
+}
+
+// IsSymlink reports whether a listing entry is a symbolic link
+func (f *USSFile) IsSymlink() bool {
+	return strings.HasPrefix(f.Mode, "l")
+}
+
+// mtimeLayouts are the mtime formats z/OSMF returns in listings
+var mtimeLayouts = []string{"2006-01-02T15:04:05", time.RFC3339}
+
+// newFileStat converts a listing entry into a USSFileStat
+func newFileStat(path string, f *USSFile) *USSFileStat {
+	stat := &USSFileStat{
+		Path:   path,
+		Type:   fileTypeFromMode(f.Mode),
+		Mode:   f.Mode,
+		Size:   f.Size,
+		UID:    f.UID,
+		User:   f.User,
+		GID:    f.GID,
+		Group:  f.Group,
+		Target: f.Target,
+	}
+	for _, layout := range mtimeLayouts {
+		if t, err := time.Parse(layout, f.MTime); err == nil {
+			stat.MTime = t
+			break
+		}
+	}
+	return stat
+}
+
+// fileTypeFromMode maps the leading character of a mode string to a FileType
+func fileTypeFromMode(mode string) FileType {
+	if mode == "" {
+		return FileTypeFile
+	}
+	switch mode[0] {
+	case 'd':
+		return FileTypeDirectory
+	case 'l':
+		return FileTypeSymlink
+	case 'c':
+		return FileTypeCharacter
+	case 'p':
+		return FileTypeFIFO
+	case 's':
+		return FileTypeSocket
+	default:
+		return FileTypeFile
+	}
+}
+
+// Permissions returns the nine rwx permission characters of the mode
+func (s *USSFileStat) Permissions() string {
+	if len(s.Mode) < 10 {
+		return ""
+	}
+	return s.Mode[1:10]
+}
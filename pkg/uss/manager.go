@@ -0,0 +1,264 @@
+package uss
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	pathpkg "path"
+	"strings"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// z/OSMF USS file API endpoints
+const (
+	// Main file system endpoint (list with ?path=)
+	FilesEndpoint = "/restfiles/fs"
+
+	// File or directory by absolute path
+	FileByPathEndpoint = "/restfiles/fs%s"
+)
+
+// NewUSSManager creates a USS manager with the given session
+func NewUSSManager(session *profile.Session) *ZOSMFUSSManager {
+	return &ZOSMFUSSManager{
+		session: session,
+	}
+}
+
+// getSession returns the manager's session, or profile.ErrNoSession when
+// the manager was created with a nil one
+func (um *ZOSMFUSSManager) getSession() (*profile.Session, error) {
+	if um.session == nil {
+		return nil, profile.ErrNoSession
+	}
+	return um.session, nil
+}
+
+// NewUSSManagerFromProfile creates a USS manager from a profile
+func NewUSSManagerFromProfile(profile *profile.ZOSMFProfile) (*ZOSMFUSSManager, error) {
+	session, err := profile.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return NewUSSManager(session), nil
+}
+
+// ListDirectory lists the entries of a directory
+func (um *ZOSMFUSSManager) ListDirectory(path string) (*USSFileList, error) {
+	session, err := um.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build URL
+	params := url.Values{}
+	params.Set("path", path)
+	return um.list(session, params, nil)
+}
+
+// StatFile returns the attributes of a single file, directory or symlink.
+// Symlinks are described themselves rather than their targets.
+func (um *ZOSMFUSSManager) StatFile(filePath string) (*USSFileStat, error) {
+	session, err := um.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	// List just this entry in its parent directory
+	cleaned := pathpkg.Clean(filePath)
+	dir, name := pathpkg.Dir(cleaned), pathpkg.Base(cleaned)
+	if name == "/" {
+		// The root directory lists itself as "."
+		name = "."
+	}
+	params := url.Values{}
+	params.Set("path", dir)
+	params.Set("name", name)
+
+	fileList, err := um.list(session, params, map[string]string{"X-IBM-Lstat": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range fileList.Items {
+		if item.Name == name {
+			return newFileStat(filePath, &item), nil
+		}
+	}
+	return nil, fmt.Errorf("file %s not found", filePath)
+}
+
+// list issues a directory listing request
+func (um *ZOSMFUSSManager) list(session *profile.Session, params url.Values, headers map[string]string) (*USSFileList, error) {
+	var raw json.RawMessage
+	path := FilesEndpoint + "?" + params.Encode()
+	if err := session.DoJSONWithHeaders(context.Background(), "GET", path, nil, &raw, headers); err != nil {
+		return nil, err
+	}
+
+	// Parse response
+	var fileList USSFileList
+	if err := profile.DecodeJSON(raw, &fileList, um.StrictJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	fileList.Truncated = fileList.MoreRows || fileList.ReturnedRows < fileList.TotalRows
+
+	return &fileList, nil
+}
+
+// ReadFile reads a text file
+func (um *ZOSMFUSSManager) ReadFile(path string) (string, error) {
+	content, err := um.readFile(path, DataTypeText)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// ReadFileBinary reads a file's raw bytes without any conversion
+func (um *ZOSMFUSSManager) ReadFileBinary(path string) ([]byte, error) {
+	return um.readFile(path, DataTypeBinary)
+}
+
+// readFile downloads a file with the given transfer mode
+func (um *ZOSMFUSSManager) readFile(path string, dataType DataType) ([]byte, error) {
+	session, err := um.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"X-IBM-Data-Type": string(dataType)}
+	resp, err := session.DoRawWithHeaders(context.Background(), "GET", filePath(path), nil, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := session.ReadBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return body, nil
+}
+
+// WriteFile writes text content to a file, creating it if needed
+func (um *ZOSMFUSSManager) WriteFile(path, content string) error {
+	return um.writeFile(path, []byte(content), DataTypeText)
+}
+
+// WriteFileBinary writes raw bytes to a file without any conversion
+func (um *ZOSMFUSSManager) WriteFileBinary(path string, data []byte) error {
+	return um.writeFile(path, data, DataTypeBinary)
+}
+
+// writeFile uploads content with the given transfer mode
+func (um *ZOSMFUSSManager) writeFile(path string, content []byte, dataType DataType) error {
+	session, err := um.getSession()
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		"Content-Type":    "text/plain",
+		"X-IBM-Data-Type": string(dataType),
+	}
+	if dataType == DataTypeBinary {
+		headers["Content-Type"] = "application/octet-stream"
+	}
+
+	resp, err := session.DoRawWithHeaders(context.Background(), "PUT", filePath(path), bytes.NewReader(content), headers)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// CreateDirectory creates a directory with the given mode (e.g. "rwxr-xr-x")
+func (um *ZOSMFUSSManager) CreateDirectory(path, mode string) error {
+	return um.create(path, "directory", mode)
+}
+
+// CreateFile creates an empty file with the given mode (e.g. "rw-r--r--")
+func (um *ZOSMFUSSManager) CreateFile(path, mode string) error {
+	return um.create(path, "file", mode)
+}
+
+// create issues the z/OSMF create request for a file or directory
+func (um *ZOSMFUSSManager) create(path, fileType, mode string) error {
+	requestBody := map[string]interface{}{
+		"type": fileType,
+	}
+	if mode != "" {
+		requestBody["mode"] = mode
+	}
+	return um.sendJSON("POST", path, requestBody, nil)
+}
+
+// Delete removes a file or directory. Non-empty directories need recursive.
+func (um *ZOSMFUSSManager) Delete(path string, recursive bool) error {
+	headers := map[string]string{}
+	if recursive {
+		headers["X-IBM-Option"] = "recursive"
+	}
+	return um.sendJSON("DELETE", path, nil, headers)
+}
+
+// Chmod changes the permissions of a file or directory. Mode can be octal
+// ("755") or symbolic ("rwxr-xr-x").
+func (um *ZOSMFUSSManager) Chmod(path, mode string) error {
+	requestBody := map[string]interface{}{
+		"request": "chmod",
+		"mode":    mode,
+	}
+	return um.sendJSON("PUT", path, requestBody, nil)
+}
+
+// sendJSON sends a request against a path with an optional JSON body
+func (um *ZOSMFUSSManager) sendJSON(method, path string, requestBody map[string]interface{}, headers map[string]string) error {
+	session, err := um.getSession()
+	if err != nil {
+		return err
+	}
+
+	// A nil map would still marshal to "null"
+	var body any
+	if requestBody != nil {
+		body = requestBody
+	}
+	return session.DoJSONWithHeaders(context.Background(), method, filePath(path), body, nil, headers)
+}
+
+// filePath is the z/OSMF path of a USS file or directory
+func filePath(path string) string {
+	return fmt.Sprintf(FileByPathEndpoint, escapePath(path))
+}
+
+// escapePath escapes each segment of an absolute USS path for the URL
+func escapePath(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// CloseUSSManager closes the USS manager and its underlying HTTP connections
+func (um *ZOSMFUSSManager) CloseUSSManager() error {
+	session := um.session
+	if session == nil {
+		return nil
+	}
+
+	// Close idle connections in the HTTP client
+	if client := session.GetHTTPClient(); client != nil {
+		client.CloseIdleConnections()
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package uss
+
+import (
+	"time"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+)
+
+// DataType represents the z/OSMF transfer mode (X-IBM-Data-Type)
+type DataType string
+
+const (
+	DataTypeText   DataType = "text"   // Codepage conversion and line handling
+	DataTypeBinary DataType = "binary" // Raw bytes, no conversion
+)
+
+// USSFile represents an entry in a z/OS UNIX directory listing
+type USSFile struct {
+	Name   string `json:"name"`             // File name
+	Mode   string `json:"mode"`             // Permission string, e.g. -rwxr-xr-x
+	Size   int64  `json:"size"`             // Size in bytes
+	UID    int    `json:"uid"`              // Owner user ID number
+	User   string `json:"user,omitempty"`   // Owner user name
+	GID    int    `json:"gid"`              // Group ID number
+	Group  string `json:"group,omitempty"`  // Group name
+	MTime  string `json:"mtime"`            // Last modification time
+	Target string `json:"target,omitempty"` // Symlink target
+}
+
+// USSFileList represents a directory listing
+type USSFileList struct {
+	Items        []USSFile `json:"items"`        // Directory entries
+	ReturnedRows int       `json:"returnedRows"` // Rows returned
+	TotalRows    int       `json:"totalRows"`    // Rows available
+	MoreRows     bool      `json:"moreRows"`     // More data available
+	JSONVersion  int       `json:"JSONversion"`  // API version
+
+	// Truncated is set when the listing hit X-IBM-Max-Items and more rows exist
+	Truncated bool `json:"-"`
+}
+
+// FileType is the kind of a z/OS UNIX file, taken from its mode string
+type FileType string
+
+const (
+	FileTypeFile      FileType = "file"
+	FileTypeDirectory FileType = "directory"
+	FileTypeSymlink   FileType = "symlink"
+	FileTypeCharacter FileType = "character"
+	FileTypeFIFO      FileType = "fifo"
+	FileTypeSocket    FileType = "socket"
+)
+
+// USSFileStat describes a single file, as returned by StatFile
+type USSFileStat struct {
+	Path   string    // Absolute path that was stat'ed
+	Type   FileType  // File, directory, symlink, ...
+	Mode   string    // Permission string, e.g. -rwxr-xr-x
+	Size   int64     // Size in bytes
+	UID    int       // Owner user ID number
+	User   string    // Owner user name
+	GID    int       // Group ID number
+	Group  string    // Group name
+	MTime  time.Time // Last modification time
+	Target string    // Symlink target
+}
+
+// USSManager interface for z/OS UNIX file operations
+type USSManager interface {
+	ListDirectory(path string) (*USSFileList, error)
+	StatFile(path string) (*USSFileStat, error)
+	ReadFile(path string) (string, error)
+	WriteFile(path, content string) error
+	CreateDirectory(path, mode string) error
+	CreateFile(path, mode string) error
+	Delete(path string, recursive bool) error
+	Chmod(path, mode string) error
+}
+
+// ZOSMFUSSManager implements USSManager for ZOSMF
+type ZOSMFUSSManager struct {
+	session *profile.Session
+
+	// StrictJSON decodes responses in profile.DecodeJSON's strict mode
+	StrictJSON bool
+}
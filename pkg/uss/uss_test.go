@@ -0,0 +1,298 @@
+package uss
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojuschugh1/zowe-client-go-sdk/pkg/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestProfile creates a profile for testing with the given server URL
+func createTestProfile(serverURL string) *profile.ZOSMFProfile {
+	// Extract host and port from server URL
+	host := strings.TrimPrefix(serverURL, "http://")
+	host = strings.TrimPrefix(host, "https://")
+
+	return &profile.ZOSMFProfile{
+		Name:               "test",
+		Host:               host,
+		Port:               0, // Let the session determine the port from the URL
+		User:               "testuser",
+		Password:           "testpass",
+		RejectUnauthorized: false,
+		BasePath:           "/api/v1",
+		Protocol:           "http", // Force HTTP for test server
+	}
+}
+
+// newTestManager creates a USS manager pointed at a test server
+func newTestManager(t *testing.T, handler http.HandlerFunc) (*ZOSMFUSSManager, func()) {
+	server := httptest.NewServer(handler)
+	session, err := createTestProfile(server.URL).NewSession()
+	require.NoError(t, err)
+	return NewUSSManager(session), server.Close
+}
+
+func TestNewUSSManager(t *testing.T) {
+	session, err := createTestProfile("localhost:8080").NewSession()
+	require.NoError(t, err)
+
+	um := NewUSSManager(session)
+	assert.NotNil(t, um)
+	assert.Equal(t, session, um.session)
+}
+
+func TestNewUSSManagerFromProfile(t *testing.T) {
+	um, err := NewUSSManagerFromProfile(createTestProfile("localhost:8080"))
+	require.NoError(t, err)
+	assert.NotNil(t, um)
+}
+
+func TestNewUSSManagerNilSession(t *testing.T) {
+	// A manager built without a session reports an error instead of panicking
+	um := NewUSSManager(nil)
+
+	_, err := um.ListDirectory("/u/test")
+	assert.ErrorIs(t, err, profile.ErrNoSession)
+
+	assert.ErrorIs(t, um.WriteFile("/u/test/file", "data"), profile.ErrNoSession)
+
+	assert.NoError(t, um.CloseUSSManager())
+}
+
+func TestCreateUSSManager(t *testing.T) {
+	pm := &profile.ZOSMFProfileManager{}
+
+	// This should fail since we don't have a real profile
+	_, err := CreateUSSManager(pm, "nonexistent")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get ZOSMF profile")
+}
+
+func TestCreateUSSManagerDirect(t *testing.T) {
+	um, err := CreateUSSManagerDirect("localhost", 8080, "testuser", "testpass")
+	require.NoError(t, err)
+	assert.NotNil(t, um)
+
+	um, err = CreateUSSManagerDirectWithOptions("localhost", 8080, "testuser", "testpass", false, "/api/v1")
+	require.NoError(t, err)
+	assert.NotNil(t, um)
+}
+
+func TestListDirectory(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs", r.URL.Path)
+		assert.Equal(t, "/u/testuser", r.URL.Query().Get("path"))
+
+		// Captured z/OSMF listing
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[
+			{"name":".","mode":"drwxr-xr-x","size":8192,"uid":0,"user":"TESTUSER","gid":1,"group":"OMVSGRP","mtime":"2023-05-01T10:15:00"},
+			{"name":"run.sh","mode":"-rwxr--r--","size":120,"uid":0,"user":"TESTUSER","gid":1,"group":"OMVSGRP","mtime":"2023-05-02T08:00:00"},
+			{"name":"link","mode":"lrwxrwxrwx","size":6,"uid":0,"user":"TESTUSER","gid":1,"group":"OMVSGRP","mtime":"2023-05-02T08:00:00","target":"run.sh"}
+		],"returnedRows":3,"totalRows":3,"JSONversion":1}`))
+	})
+	defer closeServer()
+
+	list, err := um.ListDirectory("/u/testuser")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 3)
+	assert.Equal(t, 3, list.TotalRows)
+	assert.True(t, list.Items[0].IsDirectory())
+	assert.Equal(t, "run.sh", list.Items[1].Name)
+	assert.Equal(t, int64(120), list.Items[1].Size)
+	assert.False(t, list.Items[1].IsDirectory())
+	assert.True(t, list.Items[2].IsSymlink())
+	assert.Equal(t, "run.sh", list.Items[2].Target)
+}
+
+func TestReadFile(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs/u/testuser/my file.txt", r.URL.Path)
+		w.Write([]byte("file " + r.Header.Get("X-IBM-Data-Type")))
+	})
+	defer closeServer()
+
+	content, err := um.ReadFile("/u/testuser/my file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "file text", content)
+
+	data, err := um.ReadFileBinary("/u/testuser/my file.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("file binary"), data)
+}
+
+func TestWriteFile(t *testing.T) {
+	var dataType, contentType string
+	var body []byte
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs/u/testuser/out.bin", r.URL.Path)
+		dataType = r.Header.Get("X-IBM-Data-Type")
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer closeServer()
+
+	err := um.WriteFile("/u/testuser/out.bin", "hello\n")
+	require.NoError(t, err)
+	assert.Equal(t, "text", dataType)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, "hello\n", string(body))
+
+	raw := []byte{0x00, 0x0D, 0x0A, 0xFF}
+	err = um.WriteFileBinary("/u/testuser/out.bin", raw)
+	require.NoError(t, err)
+	assert.Equal(t, "binary", dataType)
+	assert.Equal(t, "application/octet-stream", contentType)
+	assert.Equal(t, raw, body)
+}
+
+func TestCreateDirectoryAndFile(t *testing.T) {
+	var bodies []map[string]interface{}
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs/u/testuser/new", r.URL.Path)
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer closeServer()
+
+	require.NoError(t, um.CreateDirectory("/u/testuser/new", "rwxr-xr-x"))
+	require.NoError(t, um.CreateFile("/u/testuser/new", ""))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]interface{}{"type": "directory", "mode": "rwxr-xr-x"}, bodies[0])
+	assert.Equal(t, map[string]interface{}{"type": "file"}, bodies[1])
+}
+
+func TestDelete(t *testing.T) {
+	var option string
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs/u/testuser/old", r.URL.Path)
+		option = r.Header.Get("X-IBM-Option")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer closeServer()
+
+	require.NoError(t, um.Delete("/u/testuser/old", false))
+	assert.Empty(t, option)
+
+	require.NoError(t, um.Delete("/u/testuser/old", true))
+	assert.Equal(t, "recursive", option)
+}
+
+func TestChmod(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs/u/testuser/run.sh", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"request": "chmod", "mode": "755"}, body)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer closeServer()
+
+	require.NoError(t, um.Chmod("/u/testuser/run.sh", "755"))
+}
+
+func TestListDirectoryTruncated(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[{"name":"a"},{"name":"b"}],"returnedRows":2,"totalRows":5,"JSONversion":1}`))
+	})
+	defer closeServer()
+
+	list, err := um.ListDirectory("/u/testuser")
+	require.NoError(t, err)
+	assert.True(t, list.Truncated)
+}
+
+func TestStatFile(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/restfiles/fs", r.URL.Path)
+		assert.Equal(t, "/u/testuser", r.URL.Query().Get("path"))
+		assert.Equal(t, "true", r.Header.Get("X-IBM-Lstat"))
+
+		// Captured z/OSMF fixture for a single entry
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("name") {
+		case "run.sh":
+			w.Write([]byte(`{"items":[{"name":"run.sh","mode":"-rwxr-x---","size":120,"uid":1001,"user":"TESTUSER","gid":100,"group":"OMVSGRP","mtime":"2023-05-02T08:00:00"}],"returnedRows":1,"totalRows":1,"JSONversion":1}`))
+		case "current":
+			w.Write([]byte(`{"items":[{"name":"current","mode":"lrwxrwxrwx","size":10,"uid":1001,"user":"TESTUSER","gid":100,"group":"OMVSGRP","mtime":"2023-05-03T09:30:00","target":"release-2"}],"returnedRows":1,"totalRows":1,"JSONversion":1}`))
+		default:
+			w.Write([]byte(`{"items":[],"returnedRows":0,"totalRows":0,"JSONversion":1}`))
+		}
+	})
+	defer closeServer()
+
+	stat, err := um.StatFile("/u/testuser/run.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "/u/testuser/run.sh", stat.Path)
+	assert.Equal(t, FileTypeFile, stat.Type)
+	assert.Equal(t, "rwxr-x---", stat.Permissions())
+	assert.Equal(t, int64(120), stat.Size)
+	assert.Equal(t, 1001, stat.UID)
+	assert.Equal(t, "TESTUSER", stat.User)
+	assert.Equal(t, 100, stat.GID)
+	assert.Equal(t, "OMVSGRP", stat.Group)
+	assert.Equal(t, time.Date(2023, 5, 2, 8, 0, 0, 0, time.UTC), stat.MTime)
+
+	stat, err = um.StatFile("/u/testuser/current")
+	require.NoError(t, err)
+	assert.Equal(t, FileTypeSymlink, stat.Type)
+	assert.Equal(t, "release-2", stat.Target)
+
+	_, err = um.StatFile("/u/testuser/missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestUSSErrors(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	})
+	defer closeServer()
+
+	_, err := um.ListDirectory("/u/missing")
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+	_, err = um.ReadFile("/u/missing")
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+	err = um.WriteFile("/u/missing/x", "data")
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+	err = um.Delete("/u/missing", false)
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+	err = um.Chmod("/u/missing", "755")
+	assert.Contains(t, err.Error(), "API request failed with status 404")
+}
+
+func TestValidatePath(t *testing.T) {
+	assert.NoError(t, ValidatePath("/u/testuser/file.txt"))
+	assert.Error(t, ValidatePath(""))
+	assert.Error(t, ValidatePath("relative/path"))
+	assert.Error(t, ValidatePath("/"+strings.Repeat("a", 1023)))
+}
+
+func TestCloseUSSManager(t *testing.T) {
+	um, closeServer := newTestManager(t, func(w http.ResponseWriter, r *http.Request) {})
+	defer closeServer()
+
+	assert.NoError(t, um.CloseUSSManager())
+}